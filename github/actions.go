@@ -0,0 +1,200 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+)
+
+// The go-github version we use predates Github Actions, so these methods
+// build their requests by hand
+
+// WorkflowRun is one run of a Github Actions workflow
+type WorkflowRun struct {
+	ID   int64
+	Name string
+	// Status is "queued", "in_progress" or "completed"
+	Status string
+	// Conclusion is set once the run is completed, e.g. "success" or "failure"
+	Conclusion string
+	HTMLURL    string
+}
+
+// ListWorkflowRuns returns the most recent Github Actions runs on branch,
+// newest first, or on all branches if branch is empty. Busy repos have
+// thousands of runs, so only the first page is fetched: the Client's PerPage
+// runs, or Github's default of 30 if it isn't set. Returns ErrActionsDisabled
+// if the repo doesn't use Actions
+func (c *Client) ListWorkflowRuns(org, repo, branch string) ([]WorkflowRun, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	if branch != "" {
+		q.Set("branch", branch)
+	}
+	if c.PerPage > 0 {
+		q.Set("per_page", fmt.Sprint(c.PerPage))
+	}
+	u := fmt.Sprintf("repos/%s/%s/actions/runs?%s", org, repo, q.Encode())
+	req, err := c.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		WorkflowRuns []struct {
+			ID         int64  `json:"id"`
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			HTMLURL    string `json:"html_url"`
+		} `json:"workflow_runs"`
+	}
+	resp, err := c.do("Actions.ListRepositoryWorkflowRuns", func(ctx context.Context) (*github.Response, error) {
+		return c.client.Do(ctx, req, &result)
+	})
+	if err := actionsError(resp, err, repo); err != nil {
+		return nil, fmt.Errorf("Could not fetch workflow runs for %s: %w", repo, err)
+	}
+	runs := make([]WorkflowRun, 0, len(result.WorkflowRuns))
+	for _, r := range result.WorkflowRuns {
+		runs = append(runs, WorkflowRun{
+			ID:         r.ID,
+			Name:       r.Name,
+			Status:     r.Status,
+			Conclusion: r.Conclusion,
+			HTMLURL:    r.HTMLURL,
+		})
+	}
+	return runs, nil
+}
+
+// RerunWorkflow reruns a Github Actions workflow run and returns a message
+// saying it was queued. Returns ErrCannotRerun if Github refuses, e.g.
+// because the run hasn't finished yet
+func (c *Client) RerunWorkflow(org, repo string, runID int64) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if msg, ok := c.dryRun("rerun workflow run %d in %s/%s", runID, org, repo); ok {
+		return msg, nil
+	}
+	u := fmt.Sprintf("repos/%s/%s/actions/runs/%d/rerun", org, repo, runID)
+	resp, err := c.do("Actions.RerunWorkflowByID", func(ctx context.Context) (*github.Response, error) {
+		req, err := c.client.NewRequest("POST", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.client.Do(ctx, req, nil)
+	})
+	if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusConflict) {
+		if _, ok := retryWait(resp, err); !ok {
+			return "", fmt.Errorf("%w: run %d in %s: %s", ErrCannotRerun, runID, repo, errorMessage(err))
+		}
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("Workflow run %d not found in repo %s", runID, repo)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when rerunning workflow run %d: %w", runID, newAPIError(resp, err))
+	}
+	return fmt.Sprintf("*Rerun of workflow run %d in %s queued*", runID, repo), nil
+}
+
+// DownloadArtifact returns the zip of a Github Actions artifact. Github
+// redirects to the file in blob storage, which is fetched without the
+// Client's authentication so the token isn't sent to another host. Returns
+// ErrArtifactExpired once Github has deleted it. The caller must close the
+// returned reader. The zip is read after the call returns, so CallTimeout
+// doesn't apply, only the Client's HTTP timeout
+func (c *Client) DownloadArtifact(org, repo string, artifactID int64) (io.ReadCloser, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("repos/%s/%s/actions/artifacts/%d/zip", org, repo, artifactID)
+	var body io.ReadCloser
+	resp, err := c.do("Actions.DownloadArtifact", func(context.Context) (*github.Response, error) {
+		req, err := c.client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		// go-github would read the whole zip into memory, so this streams it.
+		// ctx is cancelled when this returns, before the body is read
+		httpResp, err := c.noRedirectHTTPClient().Do(req.WithContext(c.requestContext()))
+		if err != nil {
+			return nil, err
+		}
+		if loc, err := httpResp.Location(); err == nil && httpResp.StatusCode/100 == 3 {
+			httpResp.Body.Close()
+			blob, err := http.NewRequest("GET", loc.String(), nil)
+			if err != nil {
+				return nil, err
+			}
+			httpResp, err = c.unauthenticatedHTTPClient().Do(blob.WithContext(c.requestContext()))
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp := &github.Response{Response: httpResp}
+		if err := github.CheckResponse(httpResp); err != nil {
+			httpResp.Body.Close()
+			return resp, err
+		}
+		body = httpResp.Body
+		return resp, nil
+	})
+	if resp != nil && resp.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("%w: artifact %d in %s", ErrArtifactExpired, artifactID, repo)
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("Artifact %d not found in repo %s", artifactID, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not download artifact %d: %w", artifactID, newAPIError(resp, err))
+	}
+	return body, nil
+}
+
+// noRedirectHTTPClient returns a copy of the Client's HTTP client that
+// returns redirects instead of following them
+func (c *Client) noRedirectHTTPClient() *http.Client {
+	hc := *c.httpClient
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &hc
+}
+
+// unauthenticatedHTTPClient returns a copy of the Client's HTTP client
+// without the oauth2 transport that adds its token
+func (c *Client) unauthenticatedHTTPClient() *http.Client {
+	hc := *c.httpClient
+	if t, ok := hc.Transport.(*oauth2.Transport); ok {
+		hc.Transport = t.Base
+	}
+	return &hc
+}
+
+// actionsError explains errors from the Actions API. Github responds with a
+// 403 for repos with Actions disabled
+func actionsError(resp *github.Response, err error, repo string) error {
+	if err == nil {
+		return nil
+	}
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+		case http.StatusForbidden:
+			if _, ok := retryWait(resp, err); !ok {
+				return fmt.Errorf("%w: %s", ErrActionsDisabled, repo)
+			}
+		}
+	}
+	return newAPIError(resp, err)
+}
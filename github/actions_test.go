@@ -0,0 +1,186 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestListWorkflowRuns(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("branch"); got != "main" {
+			t.Errorf("got branch %q, want main", got)
+		}
+		fmt.Fprint(w, `{
+			"total_count": 3,
+			"workflow_runs": [
+				{"id": 3, "name": "CI", "status": "in_progress", "conclusion": null, "head_branch": "main", "html_url": "https://github.com/o/r/actions/runs/3"},
+				{"id": 2, "name": "CI", "status": "completed", "conclusion": "failure", "head_branch": "main", "html_url": "https://github.com/o/r/actions/runs/2"},
+				{"id": 1, "name": "Release", "status": "completed", "conclusion": "success", "head_branch": "main", "html_url": "https://github.com/o/r/actions/runs/1"}
+			]
+		}`)
+	})
+
+	got, err := c.ListWorkflowRuns("o", "r", "main")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []WorkflowRun{
+		{ID: 3, Name: "CI", Status: "in_progress", HTMLURL: "https://github.com/o/r/actions/runs/3"},
+		{ID: 2, Name: "CI", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/o/r/actions/runs/2"},
+		{ID: 1, Name: "Release", Status: "completed", Conclusion: "success", HTMLURL: "https://github.com/o/r/actions/runs/1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListWorkflowRunsAllBranches(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["branch"]; ok {
+			t.Errorf("got branch %q, want it left out", r.URL.Query().Get("branch"))
+		}
+		fmt.Fprint(w, `{"total_count": 1, "workflow_runs": [{"id": 1, "name": "CI", "status": "queued"}]}`)
+	})
+
+	got, err := c.ListWorkflowRuns("o", "r", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []WorkflowRun{{ID: 1, Name: "CI", Status: "queued"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListWorkflowRunsDisabled(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Actions is disabled for this repository."}`, http.StatusForbidden)
+	})
+
+	if _, err := c.ListWorkflowRuns("o", "r", "main"); !errors.Is(err, ErrActionsDisabled) {
+		t.Errorf("got error %v, want %v", err, ErrActionsDisabled)
+	}
+}
+
+func TestRerunWorkflow(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r/actions/runs/42/rerun", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost {
+			t.Errorf("got method %s, want POST", r.Method)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/repos/o/r/actions/runs/43/rerun", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "This workflow run is not completed"}`, http.StatusForbidden)
+	})
+
+	got, err := c.RerunWorkflow("o", "r", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "*Rerun of workflow run 42 in r queued*"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if calls != 1 {
+		t.Errorf("got %d API calls, want 1", calls)
+	}
+
+	if _, err := c.RerunWorkflow("o", "r", 43); !errors.Is(err, ErrCannotRerun) {
+		t.Errorf("got error %v, want %v", err, ErrCannotRerun)
+	}
+}
+
+func TestDownloadArtifactRedirectWithoutToken(t *testing.T) {
+	blob := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("got Authorization %q sent to blob storage, want none", got)
+		}
+		fmt.Fprint(w, "zip")
+	}))
+	defer blob.Close()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	c := NewClient("t0ken")
+	c.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	mux.HandleFunc("/repos/o/r/actions/artifacts/7/zip", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer t0ken" {
+			t.Errorf("got Authorization %q, want the API key", got)
+		}
+		http.Redirect(w, r, blob.URL+"/artifact.zip", http.StatusFound)
+	})
+
+	body, err := c.DownloadArtifact("o", "r", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer body.Close()
+	if data, _ := ioutil.ReadAll(body); string(data) != "zip" {
+		t.Errorf("got body %q, want %q", data, "zip")
+	}
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	// a zip holding build.log
+	var artifact bytes.Buffer
+	zw := zip.NewWriter(&artifact)
+	f, _ := zw.Create("build.log")
+	f.Write([]byte("FAIL"))
+	zw.Close()
+
+	mux.HandleFunc("/repos/o/r/actions/artifacts/7/zip", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://"+r.Host+"/blob/artifact.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/blob/artifact.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(artifact.Bytes())
+	})
+	mux.HandleFunc("/repos/o/r/actions/artifacts/8/zip", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Artifact has expired"}`, http.StatusGone)
+	})
+
+	body, err := c.DownloadArtifact("o", "r", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, err := ioutil.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("could not read zip: %s", err)
+	}
+	if len(zr.File) != 1 || zr.File[0].Name != "build.log" {
+		t.Errorf("got zip files %v, want build.log", zr.File)
+	}
+
+	if _, err := c.DownloadArtifact("o", "r", 8); !errors.Is(err, ErrArtifactExpired) {
+		t.Errorf("got error %v, want %v", err, ErrArtifactExpired)
+	}
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+)
+
+// appJWTLifetime is how long the JWTs used to fetch installation tokens are
+// valid for. Github allows at most 10 minutes
+const appJWTLifetime = 9 * time.Minute
+
+// NewAppClient creates a new Client that authenticates as installationID of
+// the Github App appID, using the App's PEM encoded private key. Installation
+// tokens are fetched as needed and refreshed before they expire.
+func NewAppClient(appID, installationID int64, privateKeyPEM []byte, opts ...Option) (*Client, error) {
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid Github App private key: %w", err)
+	}
+	ts := &installationTokenSource{
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+	}
+	httpClient := oauth2.NewClient(oauth2.NoContext, oauth2.ReuseTokenSource(nil, ts))
+	httpClient.Timeout = DefaultTimeout
+	client := github.NewClient(httpClient)
+	// the token source builds its requests with the client, so they go to
+	// the same Github as everything else
+	ts.client = client
+	c := newClient(client, httpClient, true)
+	c.app = true
+	c.apply(opts)
+	return c, nil
+}
+
+// parsePrivateKey parses the RSA private key Github generates for an App
+func parsePrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA key")
+	}
+	return key, nil
+}
+
+// appJWT returns a JWT signed with key that authenticates as the App
+func appJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		// backdated to allow for clock drift
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// installationTokenSource is an oauth2.TokenSource that exchanges a JWT for a
+// Github App installation token. Wrap it in oauth2.ReuseTokenSource so a new
+// token is only fetched when the last one is about to expire.
+type installationTokenSource struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	client         *github.Client
+}
+
+func (ts *installationTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := appJWT(ts.appID, ts.key, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("Could not sign Github App JWT: %w", err)
+	}
+	u := fmt.Sprintf("app/installations/%d/access_tokens", ts.installationID)
+	req, err := ts.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.machine-man-preview+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	// This can't go through ts.client, whose transport is waiting on this token
+	resp, err := (&http.Client{Timeout: DefaultTimeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch Github App installation token: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := github.CheckResponse(resp); err != nil {
+		return nil, fmt.Errorf("Could not fetch Github App installation token: %w", err)
+	}
+	var token github.InstallationToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		Expiry:      token.GetExpiresAt(),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// verifyJWT checks jwt was signed by key and returns its claims
+func verifyJWT(t *testing.T, jwt string, key *rsa.PublicKey) map[string]interface{} {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("got JWT %q, want 3 parts", jwt)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		t.Fatalf("JWT signature doesn't verify: %s", err)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(b, &claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func TestNewAppClient(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	c, err := NewAppClient(1234, 42, keyPEM)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	c.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	exchanges := 0
+	mux.HandleFunc("/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		claims := verifyJWT(t, jwt, &key.PublicKey)
+		if claims["iss"] != "1234" {
+			t.Errorf("got iss %v, want 1234", claims["iss"])
+		}
+		if exp := int64(claims["exp"].(float64)); exp > time.Now().Add(10*time.Minute).Unix() {
+			t.Errorf("got exp %d, more than 10 minutes away", exp)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "v1.installation", "expires_at": %q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	})
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer v1.installation" {
+			t.Errorf("got Authorization %q, want the installation token", got)
+		}
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+
+	c.CacheTTL = 0
+	for i := 0; i < 2; i++ {
+		if _, err := c.checkGithubRepo("o", "r"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if exchanges != 1 {
+		t.Errorf("got %d token exchanges, want 1", exchanges)
+	}
+}
+
+func TestNewAppClientBadKey(t *testing.T) {
+	if _, err := NewAppClient(1234, 42, []byte("not a key")); err == nil {
+		t.Error("expected an error, got nil")
+	}
+}
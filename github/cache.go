@@ -0,0 +1,75 @@
+package github
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a Client from NewClient remembers whether a
+// repo or branch exists
+const DefaultCacheTTL = 60 * time.Second
+
+// existsCache remembers whether repos and branches exist. It's safe for
+// concurrent use.
+type existsCache struct {
+	mu      sync.Mutex
+	entries map[string]existsEntry
+	now     func() time.Time
+}
+
+type existsEntry struct {
+	exists  bool
+	expires time.Time
+}
+
+func newExistsCache() *existsCache {
+	return &existsCache{
+		entries: make(map[string]existsEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached result for key, and whether there was an entry that
+// hasn't expired
+func (ec *existsCache) get(key string) (exists, ok bool) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	e, ok := ec.entries[key]
+	if !ok {
+		return false, false
+	}
+	if !ec.now().Before(e.expires) {
+		delete(ec.entries, key)
+		return false, false
+	}
+	return e.exists, true
+}
+
+// set caches exists for key for ttl. A ttl of zero or less isn't cached.
+// Expired entries are dropped, so keys that are never read again don't pile up
+func (ec *existsCache) set(key string, exists bool, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	now := ec.now()
+	for k, e := range ec.entries {
+		if !now.Before(e.expires) {
+			delete(ec.entries, k)
+		}
+	}
+	ec.entries[key] = existsEntry{exists: exists, expires: now.Add(ttl)}
+}
+
+// repoCacheKey is lowercase, as Github org and repo names aren't case
+// sensitive
+func repoCacheKey(org, repo string) string {
+	return strings.ToLower(org + "/" + repo)
+}
+
+// branchCacheKey keeps the branch's case, as branch names are case sensitive
+func branchCacheKey(org, repo, branch string) string {
+	return repoCacheKey(org, repo) + "/" + branch
+}
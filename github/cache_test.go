@@ -0,0 +1,145 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckGithubRepoCached(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	now := time.Unix(1500000000, 0)
+	c.cache.now = func() time.Time { return now }
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if found, err := c.checkGithubRepo("o", "r"); err != nil || !found {
+			t.Fatalf("got found %t, error %v, want found", found, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("got %d API calls within the TTL, want 1", calls)
+	}
+
+	now = now.Add(DefaultCacheTTL)
+	if _, err := c.checkGithubRepo("o", "r"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d API calls after the TTL, want 2", calls)
+	}
+}
+
+func TestCheckBranchCached(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	now := time.Unix(1500000000, 0)
+	c.cache.now = func() time.Time { return now }
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r/branches/missing", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message": "Branch not found"}`, http.StatusNotFound)
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := c.checkBranch("o", "r", "missing"); err == nil {
+			t.Fatal("expected an error for a missing branch, got nil")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("got %d API calls within the TTL, want 1", calls)
+	}
+
+	now = now.Add(DefaultCacheTTL)
+	c.checkBranch("o", "r", "missing")
+	if calls != 2 {
+		t.Errorf("got %d API calls after the TTL, want 2", calls)
+	}
+}
+
+func TestCacheKeysIgnoreCase(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/repos/Handwritingio/Foo", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"full_name": "handwritingio/foo"}`)
+	})
+
+	if found, err := c.checkGithubRepo("Handwritingio", "Foo"); err != nil || !found {
+		t.Fatalf("got %t, %v, want the repo found", found, err)
+	}
+	if found, err := c.checkGithubRepo("handwritingio", "foo"); err != nil || !found {
+		t.Errorf("got %t, %v from the cache, want the repo found", found, err)
+	}
+	if calls != 1 {
+		t.Errorf("got %d API calls, want 1", calls)
+	}
+
+	// invalidating with different casing reaches the same entry
+	c.cache.set(repoCacheKey("HANDWRITINGIO", "foo"), false, c.CacheTTL)
+	if found, err := c.checkGithubRepo("handwritingio", "Foo"); err != nil || found {
+		t.Errorf("got %t, %v, want the invalidated entry", found, err)
+	}
+}
+
+func TestExistsCacheDropsExpiredOnSet(t *testing.T) {
+	ec := newExistsCache()
+	now := time.Unix(1500000000, 0)
+	ec.now = func() time.Time { return now }
+
+	ec.set("old", true, time.Second)
+	now = now.Add(2 * time.Second)
+	ec.set("new", true, time.Second)
+	if _, ok := ec.entries["old"]; ok {
+		t.Error("expired entry kept after set")
+	}
+	if _, ok := ec.entries["new"]; !ok {
+		t.Error("new entry missing")
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.CacheTTL = 0
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+
+	c.checkGithubRepo("o", "r")
+	c.checkGithubRepo("o", "r")
+	if calls != 2 {
+		t.Errorf("got %d API calls, want 2", calls)
+	}
+}
+
+func TestExistsCacheConcurrent(t *testing.T) {
+	ec := newExistsCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := repoCacheKey("o", fmt.Sprint(i%3))
+			ec.set(key, true, time.Minute)
+			ec.get(key)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// CheckRunStatuses lists the check run statuses Github accepts
+var CheckRunStatuses = []string{"queued", "in_progress", "completed"}
+
+// CheckRunConclusions lists the conclusions Github accepts for a completed
+// check run
+var CheckRunConclusions = []string{"success", "failure", "neutral", "cancelled", "timed_out", "action_required"}
+
+// CheckRunOutput is the summary shown for a check run on Github
+type CheckRunOutput struct {
+	Title   string
+	Summary string
+}
+
+// CheckRunRequest holds the fields used to create a check run. Name and
+// HeadSHA are required
+type CheckRunRequest struct {
+	Name string
+	// HeadSHA is the commit being checked
+	HeadSHA string
+	// Status is one of CheckRunStatuses, Github uses "queued" if it's empty
+	Status string
+	// Conclusion is one of CheckRunConclusions, and is required when Status
+	// is "completed"
+	Conclusion string
+	Output     *CheckRunOutput
+}
+
+// checkCheckRun returns an error if status or conclusion aren't ones Github
+// accepts, or if a completed check run has no conclusion
+func checkCheckRun(status, conclusion string) error {
+	if status != "" && !contains(CheckRunStatuses, status) {
+		return fmt.Errorf("Invalid check run status %q: must be one of %s", status, strings.Join(CheckRunStatuses, ", "))
+	}
+	if conclusion != "" && !contains(CheckRunConclusions, conclusion) {
+		return fmt.Errorf("Invalid check run conclusion %q: must be one of %s", conclusion, strings.Join(CheckRunConclusions, ", "))
+	}
+	if status == "completed" && conclusion == "" {
+		return errors.New("A conclusion is required to complete a check run")
+	}
+	return nil
+}
+
+// contains reports whether values includes v
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// newCheckRunOutput converts output for go-github
+func newCheckRunOutput(output *CheckRunOutput) *github.CheckRunOutput {
+	if output == nil {
+		return nil
+	}
+	return &github.CheckRunOutput{
+		Title:   github.String(output.Title),
+		Summary: github.String(output.Summary),
+	}
+}
+
+// CreateCheckRun creates a check run on a commit and returns its ID. Github
+// only lets Apps create check runs, so ErrAppRequired is returned unless the
+// Client is from NewAppClient
+func (c *Client) CreateCheckRun(org, repo string, opts CheckRunRequest) (int64, error) {
+	if err := c.allowOrg(org); err != nil {
+		return 0, err
+	}
+	if opts.Name == "" || opts.HeadSHA == "" {
+		return 0, errors.New("A name and head SHA are required to create a check run")
+	}
+	if err := checkCheckRun(opts.Status, opts.Conclusion); err != nil {
+		return 0, err
+	}
+	if !c.app {
+		return 0, fmt.Errorf("Can't create check run %s: %w", opts.Name, ErrAppRequired)
+	}
+	if _, ok := c.dryRun("created check run %s on %s in %s/%s", opts.Name, opts.HeadSHA, org, repo); ok {
+		return 0, nil
+	}
+	create := github.CreateCheckRunOptions{
+		Name:    opts.Name,
+		HeadSHA: opts.HeadSHA,
+		Output:  newCheckRunOutput(opts.Output),
+	}
+	if opts.Status != "" {
+		create.Status = github.String(opts.Status)
+	}
+	if opts.Conclusion != "" {
+		create.Conclusion = github.String(opts.Conclusion)
+		// Github wants to know when a concluded check run finished
+		create.CompletedAt = &github.Timestamp{Time: time.Now()}
+	}
+	var run *github.CheckRun
+	resp, err := c.do("Checks.CreateCheckRun", func(ctx context.Context) (resp *github.Response, err error) {
+		run, resp, err = c.client.Checks.CreateCheckRun(ctx, org, repo, create)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return 0, fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Error occurred when creating check run: %w", newAPIError(resp, err))
+	}
+	return run.GetID(), nil
+}
+
+// UpdateCheckRun changes the status, conclusion and output of a check run.
+// Empty fields, and a nil output, are left as they are, but a conclusion is
+// required when status is "completed". Returns ErrAppRequired unless the
+// Client is from NewAppClient
+func (c *Client) UpdateCheckRun(org, repo string, checkRunID int64, status, conclusion string, output *CheckRunOutput) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if err := checkCheckRun(status, conclusion); err != nil {
+		return err
+	}
+	if !c.app {
+		return fmt.Errorf("Can't update check run %d: %w", checkRunID, ErrAppRequired)
+	}
+	if _, ok := c.dryRun("updated check run %d in %s/%s", checkRunID, org, repo); ok {
+		return nil
+	}
+	// go-github always sends the name, so the current one is needed to
+	// keep it
+	var run *github.CheckRun
+	resp, err := c.do("Checks.GetCheckRun", func(ctx context.Context) (resp *github.Response, err error) {
+		run, resp, err = c.client.Checks.GetCheckRun(ctx, org, repo, checkRunID)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Check run %d not found in repo %s", checkRunID, repo)
+	}
+	if err != nil {
+		return fmt.Errorf("Could not fetch check run %d: %w", checkRunID, newAPIError(resp, err))
+	}
+
+	update := github.UpdateCheckRunOptions{
+		Name:   run.GetName(),
+		Output: newCheckRunOutput(output),
+	}
+	if status != "" {
+		update.Status = github.String(status)
+	}
+	if conclusion != "" {
+		update.Conclusion = github.String(conclusion)
+		update.CompletedAt = &github.Timestamp{Time: time.Now()}
+	}
+	resp, err = c.do("Checks.UpdateCheckRun", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Checks.UpdateCheckRun(ctx, org, repo, checkRunID, update)
+		return
+	})
+	if err != nil {
+		return fmt.Errorf("Error occurred when updating check run %d: %w", checkRunID, newAPIError(resp, err))
+	}
+	return nil
+}
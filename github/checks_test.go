@@ -0,0 +1,115 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCreateCheckRun(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+	c.app = true
+
+	mux.HandleFunc("/repos/o/r/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("got method %s, want POST", r.Method)
+		}
+		var got struct {
+			Name        string
+			HeadSHA     string `json:"head_sha"`
+			Status      string
+			Conclusion  string
+			CompletedAt string `json:"completed_at"`
+			Output      struct{ Title, Summary string }
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != "lint" || got.HeadSHA != "abc123" || got.Status != "completed" || got.Conclusion != "success" {
+			t.Errorf("got check run %+v, want a successful completed lint run on abc123", got)
+		}
+		if got.CompletedAt == "" {
+			t.Error("got no completed_at for a concluded check run")
+		}
+		if got.Output.Title != "Lint" || got.Output.Summary != "No problems" {
+			t.Errorf("got output %+v, want Lint: No problems", got.Output)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 4}`)
+	})
+
+	id, err := c.CreateCheckRun("o", "r", CheckRunRequest{
+		Name:       "lint",
+		HeadSHA:    "abc123",
+		Status:     "completed",
+		Conclusion: "success",
+		Output:     &CheckRunOutput{Title: "Lint", Summary: "No problems"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != 4 {
+		t.Errorf("got ID %d, want 4", id)
+	}
+}
+
+func TestCreateCheckRunRequiresApp(t *testing.T) {
+	c, _, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	_, err := c.CreateCheckRun("o", "r", CheckRunRequest{Name: "lint", HeadSHA: "abc123"})
+	if !errors.Is(err, ErrAppRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAppRequired)
+	}
+}
+
+func TestUpdateCheckRun(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+	c.app = true
+
+	mux.HandleFunc("/repos/o/r/check-runs/4", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"id": 4, "name": "lint", "status": "in_progress"}`)
+			return
+		}
+		if r.Method != "PATCH" {
+			t.Errorf("got method %s, want PATCH", r.Method)
+		}
+		var got map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got["name"] != "lint" || got["status"] != "completed" || got["conclusion"] != "failure" {
+			t.Errorf("got update %v, want lint completed with failure", got)
+		}
+		if got["completed_at"] == nil {
+			t.Error("got no completed_at for a concluded check run")
+		}
+		fmt.Fprint(w, `{"id": 4}`)
+	})
+
+	output := &CheckRunOutput{Title: "Lint", Summary: "2 problems"}
+	if err := c.UpdateCheckRun("o", "r", 4, "completed", "failure", output); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestUpdateCheckRunNeedsConclusion(t *testing.T) {
+	c, _, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+	c.app = true
+
+	// no handlers are registered, so any API call would fail with a 404
+	err := c.UpdateCheckRun("o", "r", 4, "completed", "", nil)
+	if want := "A conclusion is required to complete a check run"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,229 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// CommitSummary is the part of a commit worth showing in chat
+type CommitSummary struct {
+	SHA string
+	// Author is who wrote the commit, their Github login if the commit is
+	// linked to a Github user and the git author name if not
+	Author string
+	// AuthorLogin is the Github login of the author, empty if the commit
+	// isn't linked to a Github user
+	AuthorLogin string
+	// Message is the first line of the commit message
+	Message string
+	HTMLURL string
+	// Merge is set for merge commits, which have more than one parent
+	Merge bool
+}
+
+func newCommitSummary(rc *github.RepositoryCommit) CommitSummary {
+	message := rc.GetCommit().GetMessage()
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		message = message[:i]
+	}
+	return CommitSummary{
+		SHA:         rc.GetSHA(),
+		Author:      commitAuthor(rc),
+		AuthorLogin: rc.GetAuthor().GetLogin(),
+		Message:     message,
+		HTMLURL:     rc.GetHTMLURL(),
+		Merge:       len(rc.Parents) > 1,
+	}
+}
+
+// commitAuthor returns who wrote a commit. It prefers the Github login, the
+// commit author's name is only known to git
+func commitAuthor(rc *github.RepositoryCommit) string {
+	if login := rc.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	return rc.GetCommit().GetAuthor().GetName()
+}
+
+// CommitDetail describes a single commit in full
+type CommitDetail struct {
+	SHA     string
+	Author  string
+	Message string
+	// Additions and Deletions are line counts across all files
+	Additions int
+	Deletions int
+	Files     []CommitFile
+	HTMLURL   string
+}
+
+// CommitFile is a file changed by a commit
+type CommitFile struct {
+	Filename string
+	// Status is "added", "removed", "modified" or "renamed"
+	Status    string
+	Additions int
+	Deletions int
+}
+
+// GetCommit returns the details of a commit, including the files it changed.
+// sha may be abbreviated, or a branch or tag name. Returns ErrCommitNotFound
+// if there's no such commit
+func (c *Client) GetCommit(org, repo, sha string) (*CommitDetail, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	var rc *github.RepositoryCommit
+	resp, err := c.do("Repositories.GetCommit", func(ctx context.Context) (resp *github.Response, err error) {
+		rc, resp, err = c.client.Repositories.GetCommit(ctx, org, repo, sha)
+		return
+	})
+	// Github responds with a 422 for SHAs that don't match a commit
+	if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+		return nil, fmt.Errorf("%w: %s in repo %s", ErrCommitNotFound, sha, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch commit %s for %s: %w", sha, repo, newAPIError(resp, err))
+	}
+	detail := &CommitDetail{
+		SHA:       rc.GetSHA(),
+		Author:    commitAuthor(rc),
+		Message:   rc.GetCommit().GetMessage(),
+		Additions: rc.GetStats().GetAdditions(),
+		Deletions: rc.GetStats().GetDeletions(),
+		Files:     make([]CommitFile, 0, len(rc.Files)),
+		HTMLURL:   rc.GetHTMLURL(),
+	}
+	for _, f := range rc.Files {
+		detail.Files = append(detail.Files, CommitFile{
+			Filename:  f.GetFilename(),
+			Status:    f.GetStatus(),
+			Additions: f.GetAdditions(),
+			Deletions: f.GetDeletions(),
+		})
+	}
+	return detail, nil
+}
+
+// ListCommits returns up to limit of the most recent commits on branch
+func (c *Client) ListCommits(org, repo, branch string, limit int) ([]CommitSummary, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.CommitsListOptions{
+		SHA:         branch,
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	if limit < opt.PerPage {
+		opt.PerPage = limit
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+	all, resp, err := paginateLimit(&opt.ListOptions, limit, func() ([]*github.RepositoryCommit, *github.Response, error) {
+		var page []*github.RepositoryCommit
+		resp, err := c.do("Repositories.ListCommits", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Repositories.ListCommits(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch commits for %s: %w", repo, newAPIError(resp, err))
+	}
+	var commits []CommitSummary
+	for _, rc := range all {
+		commits = append(commits, newCommitSummary(rc))
+	}
+	return commits, nil
+}
+
+// CompareResult describes how head differs from base
+type CompareResult struct {
+	// Status is "identical", "ahead", "behind" or "diverged"
+	Status   string
+	AheadBy  int
+	BehindBy int
+	// Commits are the commits on head that aren't on base, oldest first
+	Commits []CommitSummary
+	HTMLURL string
+}
+
+// CompareCommits compares two refs of a repo, e.g. two release tags
+func (c *Client) CompareCommits(org, repo, base, head string) (*CompareResult, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	var comparison *github.CommitsComparison
+	resp, err := c.do("Repositories.CompareCommits", func(ctx context.Context) (resp *github.Response, err error) {
+		comparison, resp, err = c.client.Repositories.CompareCommits(ctx, org, repo, base, head)
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not compare %s...%s in %s: %w", base, head, repo, newAPIError(resp, err))
+	}
+	result := &CompareResult{
+		Status:   comparison.GetStatus(),
+		AheadBy:  comparison.GetAheadBy(),
+		BehindBy: comparison.GetBehindBy(),
+		Commits:  make([]CommitSummary, 0, len(comparison.Commits)),
+		HTMLURL:  comparison.GetHTMLURL(),
+	}
+	for i := range comparison.Commits {
+		result.Commits = append(result.Commits, newCommitSummary(&comparison.Commits[i]))
+	}
+	return result, nil
+}
+
+// CombinedStatus is the CI state of a ref
+type CombinedStatus struct {
+	// State is "success", "failure" or "pending". Github reports a ref with
+	// no statuses at all as "pending", so check Statuses too
+	State    string
+	Statuses []StatusContext
+}
+
+// StatusContext is the state reported by one CI system, e.g. "ci/circleci"
+type StatusContext struct {
+	Context   string
+	State     string
+	TargetURL string
+}
+
+// GetCommitStatus returns the combined CI status of ref, which can be a
+// branch, tag or commit SHA
+func (c *Client) GetCommitStatus(org, repo, ref string) (*CombinedStatus, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.ListOptions{PerPage: c.PerPage}
+	status := &CombinedStatus{Statuses: []StatusContext{}}
+	// each page repeats the combined state and has some of the statuses
+	all, resp, err := paginate(opt, func() ([]github.RepoStatus, *github.Response, error) {
+		var page *github.CombinedStatus
+		resp, err := c.do("Repositories.GetCombinedStatus", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Repositories.GetCombinedStatus(ctx, org, repo, ref, opt)
+			return
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		status.State = page.GetState()
+		return page.Statuses, resp, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch status of %s in %s: %w", ref, repo, newAPIError(resp, err))
+	}
+	for _, s := range all {
+		status.Statuses = append(status.Statuses, StatusContext{
+			Context:   s.GetContext(),
+			State:     s.GetState(),
+			TargetURL: s.GetTargetURL(),
+		})
+	}
+	return status, nil
+}
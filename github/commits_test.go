@@ -0,0 +1,210 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestListCommits(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	pages := pagesHandler(
+		`[
+			{"sha": "a1", "html_url": "https://github.com/o/r/commit/a1", "author": {"login": "deckard"},
+			 "commit": {"message": "Fix the thing\n\nIt was broken."}},
+			{"sha": "b2", "html_url": "https://github.com/o/r/commit/b2",
+			 "commit": {"message": "Add a test", "author": {"name": "Rachael"}}}
+		]`,
+		`[{"sha": "c3", "commit": {"message": "Third"}}, {"sha": "d4", "commit": {"message": "Fourth"}}]`,
+		`[{"sha": "e5", "commit": {"message": "Fifth"}}]`,
+	)
+	mux.HandleFunc("/repos/o/r/commits", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if got := r.URL.Query().Get("sha"); got != "develop" {
+			t.Errorf("got sha %q, want develop", got)
+		}
+		pages(w, r)
+	})
+
+	got, err := c.ListCommits("o", "r", "develop", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []CommitSummary{
+		{SHA: "a1", Author: "deckard", AuthorLogin: "deckard", Message: "Fix the thing", HTMLURL: "https://github.com/o/r/commit/a1"},
+		{SHA: "b2", Author: "Rachael", Message: "Add a test", HTMLURL: "https://github.com/o/r/commit/b2"},
+		{SHA: "c3", Message: "Third"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if calls != 2 {
+		t.Errorf("got %d API calls, want 2", calls)
+	}
+}
+
+func TestCompareCommits(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/compare/v1.2...v1.3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"status": "ahead",
+			"ahead_by": 3,
+			"behind_by": 0,
+			"html_url": "https://github.com/o/r/compare/v1.2...v1.3",
+			"commits": [
+				{"sha": "a1", "author": {"login": "deckard"}, "commit": {"message": "One"}},
+				{"sha": "b2", "author": {"login": "deckard"}, "commit": {"message": "Two\n\nMore detail"}},
+				{"sha": "c3", "author": {"login": "rachael"}, "commit": {"message": "Three"}}
+			]
+		}`)
+	})
+	mux.HandleFunc("/repos/o/r/compare/v1.3...v1.3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "identical", "ahead_by": 0, "behind_by": 0, "commits": []}`)
+	})
+
+	got, err := c.CompareCommits("o", "r", "v1.2", "v1.3")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &CompareResult{
+		Status:  "ahead",
+		AheadBy: 3,
+		Commits: []CommitSummary{
+			{SHA: "a1", Author: "deckard", AuthorLogin: "deckard", Message: "One"},
+			{SHA: "b2", Author: "deckard", AuthorLogin: "deckard", Message: "Two"},
+			{SHA: "c3", Author: "rachael", AuthorLogin: "rachael", Message: "Three"},
+		},
+		HTMLURL: "https://github.com/o/r/compare/v1.2...v1.3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got, err = c.CompareCommits("o", "r", "v1.3", "v1.3")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Status != "identical" || got.AheadBy != 0 || got.BehindBy != 0 || len(got.Commits) != 0 {
+		t.Errorf("got %+v, want an identical comparison", got)
+	}
+}
+
+func TestGetCommitStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *CombinedStatus
+	}{
+		{
+			name: "success",
+			response: `{"state": "success", "statuses": [
+				{"context": "ci/build", "state": "success", "target_url": "https://ci.example.com/1"},
+				{"context": "ci/lint", "state": "success", "target_url": "https://ci.example.com/2"}
+			]}`,
+			want: &CombinedStatus{State: "success", Statuses: []StatusContext{
+				{Context: "ci/build", State: "success", TargetURL: "https://ci.example.com/1"},
+				{Context: "ci/lint", State: "success", TargetURL: "https://ci.example.com/2"},
+			}},
+		},
+		{
+			name: "failure",
+			response: `{"state": "failure", "statuses": [
+				{"context": "ci/build", "state": "success"},
+				{"context": "ci/lint", "state": "error"}
+			]}`,
+			want: &CombinedStatus{State: "failure", Statuses: []StatusContext{
+				{Context: "ci/build", State: "success"},
+				{Context: "ci/lint", State: "error"},
+			}},
+		},
+		{
+			name:     "pending",
+			response: `{"state": "pending", "statuses": [{"context": "ci/build", "state": "pending"}]}`,
+			want: &CombinedStatus{State: "pending", Statuses: []StatusContext{
+				{Context: "ci/build", State: "pending"},
+			}},
+		},
+		{
+			name:     "no statuses",
+			response: `{"state": "pending", "total_count": 0, "statuses": []}`,
+			want:     &CombinedStatus{State: "pending", Statuses: []StatusContext{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			mux.HandleFunc("/repos/o/r/commits/master/status", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.response)
+			})
+
+			got, err := c.GetCommitStatus("o", "r", "master")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCommit(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/commits/6dcb09b", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"html_url": "https://github.com/o/r/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"author": {"login": "octocat"},
+			"commit": {"message": "Fix all the bugs\n\nAnd add a test", "author": {"name": "Monalisa Octocat"}},
+			"stats": {"additions": 104, "deletions": 4, "total": 108},
+			"files": [
+				{"filename": "main.go", "status": "modified", "additions": 100, "deletions": 4},
+				{"filename": "main_test.go", "status": "added", "additions": 4, "deletions": 0},
+				{"filename": "old.go", "status": "removed", "additions": 0, "deletions": 0}
+			]
+		}`)
+	})
+	mux.HandleFunc("/repos/o/r/commits/deadbee", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message": "No commit found for SHA: deadbee"}`)
+	})
+
+	got, err := c.GetCommit("o", "r", "6dcb09b")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &CommitDetail{
+		SHA:       "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Author:    "octocat",
+		Message:   "Fix all the bugs\n\nAnd add a test",
+		Additions: 104,
+		Deletions: 4,
+		Files: []CommitFile{
+			{Filename: "main.go", Status: "modified", Additions: 100, Deletions: 4},
+			{Filename: "main_test.go", Status: "added", Additions: 4},
+			{Filename: "old.go", Status: "removed"},
+		},
+		HTMLURL: "https://github.com/o/r/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := c.GetCommit("o", "r", "deadbee"); !errors.Is(err, ErrCommitNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrCommitNotFound)
+	}
+	if _, err := c.GetCommit("o", "r", "missing"); !errors.Is(err, ErrCommitNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrCommitNotFound)
+	}
+}
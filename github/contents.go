@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/github"
+)
+
+// ContentEntry is a file or directory in a repo
+type ContentEntry struct {
+	Name string
+	// Type is "file", "dir", "symlink" or "submodule"
+	Type string
+	Path string
+}
+
+// ListContents returns the entries of the directory at path in a repo. If
+// path is a file, the file is the only entry. ref is the branch, tag or
+// commit SHA to read from, or empty for the repo's default branch.
+func (c *Client) ListContents(org, repo, path, ref string) ([]ContentEntry, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.RepositoryContentGetOptions{Ref: ref}
+	var file *github.RepositoryContent
+	var directory []*github.RepositoryContent
+	resp, err := c.do("Repositories.GetContents", func(ctx context.Context) (resp *github.Response, err error) {
+		file, directory, resp, err = c.client.Repositories.GetContents(ctx, org, repo, path, opt)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("Path %s not found in repo %s", path, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not list contents of %s in %s: %w", path, repo, newAPIError(resp, err))
+	}
+	if file != nil {
+		directory = []*github.RepositoryContent{file}
+	}
+	entries := make([]ContentEntry, 0, len(directory))
+	for _, e := range directory {
+		entries = append(entries, ContentEntry{
+			Name: e.GetName(),
+			Type: e.GetType(),
+			Path: e.GetPath(),
+		})
+	}
+	return entries, nil
+}
+
+// maxFileWorkers is how many files GetFiles fetches at once
+const maxFileWorkers = 4
+
+// GetFiles returns the contents of several files in a repo, keyed by path,
+// fetching them concurrently. ref is as for GetFile. If any can't be
+// fetched, the rest are returned along with a *FileErrors saying why. Files
+// not fetched yet when the Client's context is done fail with its error
+func (c *Client) GetFiles(org, repo, ref string, paths []string) (map[string][]byte, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	ctx := c.requestContext()
+	files := make(map[string][]byte, len(paths))
+	failed := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+	workers := maxFileWorkers
+	if len(paths) < workers {
+		workers = len(paths)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				var body []byte
+				err := ctx.Err()
+				if err == nil {
+					body, _, err = c.GetFile(org, repo, path, ref)
+				}
+				mu.Lock()
+				if err != nil {
+					failed[path] = err
+				} else {
+					files[path] = body
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, path := range paths {
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+	if len(failed) > 0 {
+		return files, &FileErrors{Repo: repo, Errors: failed}
+	}
+	return files, nil
+}
+
+// CreateOrUpdateFile commits content to path on branch, creating the file if
+// it doesn't exist yet, and returns the commit URL. If Github refuses the
+// commit, e.g. because the branch is protected, ErrCommitRejected is returned.
+// An empty branch means the repo's default branch
+func (c *Client) CreateOrUpdateFile(org, repo, path, branch, message string, content []byte) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	target := branch
+	if target == "" {
+		target = "the default branch"
+	}
+	if msg, ok := c.dryRun("committed %s to %s in %s/%s", path, target, org, repo); ok {
+		return msg, nil
+	}
+	// Updates need the SHA of the file being replaced
+	var existing *github.RepositoryContent
+	var directory []*github.RepositoryContent
+	resp, err := c.do("Repositories.GetContents", func(ctx context.Context) (resp *github.Response, err error) {
+		existing, directory, resp, err = c.client.Repositories.GetContents(ctx, org, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+		return
+	})
+	notFound := resp != nil && resp.StatusCode == http.StatusNotFound
+	if err != nil && !notFound {
+		return "", fmt.Errorf("Could not fetch %s in %s: %w", path, repo, newAPIError(resp, err))
+	}
+	if existing == nil && directory != nil {
+		return "", fmt.Errorf("%w: %s", ErrIsDirectory, path)
+	}
+
+	opt := &github.RepositoryContentFileOptions{
+		Message: github.String(message),
+		Content: content,
+	}
+	// Github rejects an empty branch rather than using the default one
+	if branch != "" {
+		opt.Branch = github.String(branch)
+	}
+	update := c.client.Repositories.CreateFile
+	method := "Repositories.CreateFile"
+	if existing != nil {
+		opt.SHA = existing.SHA
+		update = c.client.Repositories.UpdateFile
+		method = "Repositories.UpdateFile"
+	}
+	var result *github.RepositoryContentResponse
+	resp, err = c.do(method, func(ctx context.Context) (resp *github.Response, err error) {
+		result, resp, err = update(ctx, org, repo, path, opt)
+		return
+	})
+	if resp != nil && (resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusUnprocessableEntity) {
+		return "", fmt.Errorf("%w: %s on %s: %s", ErrCommitRejected, path, target, errorMessage(err))
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when committing %s: %w", path, newAPIError(resp, err))
+	}
+	return result.Commit.GetHTMLURL(), nil
+}
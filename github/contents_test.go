@@ -0,0 +1,232 @@
+package github
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestListContents(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/contents/docs", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ref"); got != "v1" {
+			t.Errorf("got ref %q, want v1", got)
+		}
+		fmt.Fprint(w, `[
+			{"type": "file", "name": "index.md", "path": "docs/index.md"},
+			{"type": "dir", "name": "images", "path": "docs/images"}
+		]`)
+	})
+	mux.HandleFunc("/repos/o/r/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type": "file", "name": "README.md", "path": "README.md", "encoding": "base64", "content": "aGVsbG8="}`)
+	})
+
+	got, err := c.ListContents("o", "r", "docs", "v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []ContentEntry{
+		{Name: "index.md", Type: "file", Path: "docs/index.md"},
+		{Name: "images", Type: "dir", Path: "docs/images"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got, err = c.ListContents("o", "r", "README.md", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want = []ContentEntry{{Name: "README.md", Type: "file", Path: "README.md"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, _, err := c.GetFile("o", "r", "docs", "v1"); !errors.Is(err, ErrIsDirectory) {
+		t.Errorf("got GetFile error %v, want %v", err, ErrIsDirectory)
+	}
+}
+
+func TestCreateOrUpdateFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		exists  bool
+		wantSHA string
+	}{
+		{name: "create"},
+		{name: "update", exists: true, wantSHA: "abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r/contents/config.yml", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					if got := r.URL.Query().Get("ref"); got != "master" {
+						t.Errorf("got ref %q, want master", got)
+					}
+					if !tt.exists {
+						http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+						return
+					}
+					fmt.Fprint(w, `{"type": "file", "sha": "abc123", "encoding": "base64", "content": "b2xk"}`)
+				case http.MethodPut:
+					var got struct {
+						Message string `json:"message"`
+						Content []byte `json:"content"`
+						SHA     string `json:"sha"`
+						Branch  string `json:"branch"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+						t.Fatal(err)
+					}
+					if got.Message != "Update config" || string(got.Content) != "new" || got.Branch != "master" {
+						t.Errorf("got request %+v", got)
+					}
+					if got.SHA != tt.wantSHA {
+						t.Errorf("got SHA %q, want %q", got.SHA, tt.wantSHA)
+					}
+					fmt.Fprint(w, `{"commit": {"sha": "def456", "html_url": "https://github.com/o/r/commit/def456"}}`)
+				}
+			})
+
+			got, err := c.CreateOrUpdateFile("o", "r", "config.yml", "master", "Update config", []byte("new"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := "https://github.com/o/r/commit/def456"; got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdateFileDefaultBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/contents/config.yml", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		var got map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if branch, ok := got["branch"]; ok {
+			t.Errorf("got branch %q, want it left out", branch)
+		}
+		fmt.Fprint(w, `{"commit": {"sha": "def456", "html_url": "https://github.com/o/r/commit/def456"}}`)
+	})
+
+	if _, err := c.CreateOrUpdateFile("o", "r", "config.yml", "", "Update config", []byte("new")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestCreateOrUpdateFileRejected(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/contents/config.yml", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"message": "Protected branch update failed"}`)
+	})
+
+	_, err := c.CreateOrUpdateFile("o", "r", "config.yml", "master", "Update config", []byte("new"))
+	if !errors.Is(err, ErrCommitRejected) {
+		t.Fatalf("got error %v, want %v", err, ErrCommitRejected)
+	}
+	want := "Github rejected the commit: config.yml on master: Protected branch update failed"
+	if err.Error() != want {
+		t.Errorf("got error %q, want %q", err.Error(), want)
+	}
+}
+
+func TestGetFiles(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	// the first fetches wait until maxFileWorkers are running at once, which
+	// only happens if GetFiles runs them concurrently
+	var mu sync.Mutex
+	var once sync.Once
+	release := make(chan struct{})
+	active, maxActive := 0, 0
+	paths := []string{"a.txt", "b.txt", "c.txt", "d.txt", "e.txt", "f.txt"}
+	for _, p := range paths {
+		content := base64.StdEncoding.EncodeToString([]byte("contents of " + p))
+		mux.HandleFunc("/repos/o/r/contents/"+p, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			if active == maxFileWorkers {
+				once.Do(func() { close(release) })
+			}
+			mu.Unlock()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+			mu.Lock()
+			active--
+			mu.Unlock()
+			fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "content": %q}`, content)
+		})
+	}
+
+	files, err := c.GetFiles("o", "r", "", paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, p := range paths {
+		if got, want := string(files[p]), "contents of "+p; got != want {
+			t.Errorf("%s: got %q, want %q", p, got, want)
+		}
+	}
+	if maxActive != maxFileWorkers {
+		t.Errorf("got %d fetches at once, want %d", maxActive, maxFileWorkers)
+	}
+}
+
+func TestGetFilesNotFound(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/contents/a.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type": "file", "encoding": "base64", "content": "YQ=="}`)
+	})
+	mux.HandleFunc("/repos/o/r/contents/gone.txt", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	files, err := c.GetFiles("o", "r", "", []string{"a.txt", "gone.txt"})
+	var fileErrs *FileErrors
+	if !errors.As(err, &fileErrs) {
+		t.Fatalf("got error %v, want *FileErrors", err)
+	}
+	if _, ok := fileErrs.Errors["gone.txt"]; !ok || len(fileErrs.Errors) != 1 {
+		t.Errorf("got failed files %v, want just gone.txt", fileErrs.Errors)
+	}
+	if string(files["a.txt"]) != "a" {
+		t.Errorf("got a.txt %q, want it fetched anyway", files["a.txt"])
+	}
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// Deployment is a deployment of a repo to an environment
+type Deployment struct {
+	ID          int64
+	Environment string
+	// Ref is the branch, tag or SHA that was deployed
+	Ref     string
+	Creator string
+}
+
+// DeploymentStatus is the state of a deployment
+type DeploymentStatus struct {
+	// State is "pending", "success", "failure", "error" or "inactive"
+	State string
+	// TargetURL links to the deployment's output, e.g. a build log
+	TargetURL string
+	Creator   string
+}
+
+// ListDeployments returns the deployments of a repo, most recent first. If
+// environment isn't empty only deployments to it are returned
+func (c *Client) ListDeployments(org, repo, environment string) ([]Deployment, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.DeploymentsListOptions{
+		Environment: environment,
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	deployments, resp, err := paginate(&opt.ListOptions, func() ([]*github.Deployment, *github.Response, error) {
+		var page []*github.Deployment
+		resp, err := c.do("Repositories.ListDeployments", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Repositories.ListDeployments(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch deployments for %s: %w", repo, newAPIError(resp, err))
+	}
+	out := make([]Deployment, 0, len(deployments))
+	for _, d := range deployments {
+		out = append(out, Deployment{
+			ID:          d.GetID(),
+			Environment: d.GetEnvironment(),
+			Ref:         d.GetRef(),
+			Creator:     d.GetCreator().GetLogin(),
+		})
+	}
+	return out, nil
+}
+
+// GetDeploymentStatus returns the latest status of a deployment
+func (c *Client) GetDeploymentStatus(org, repo string, deploymentID int64) (*DeploymentStatus, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	// Github lists statuses newest first, so only the first is needed
+	var statuses []*github.DeploymentStatus
+	resp, err := c.do("Repositories.ListDeploymentStatuses", func(ctx context.Context) (resp *github.Response, err error) {
+		statuses, resp, err = c.client.Repositories.ListDeploymentStatuses(ctx, org, repo, deploymentID, &github.ListOptions{PerPage: 1})
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("Deployment %d not found in repo %s", deploymentID, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch status of deployment %d: %w", deploymentID, newAPIError(resp, err))
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("Deployment %d in %s has no status yet", deploymentID, repo)
+	}
+	s := statuses[0]
+	return &DeploymentStatus{
+		State:     s.GetState(),
+		TargetURL: s.GetTargetURL(),
+		Creator:   s.GetCreator().GetLogin(),
+	}, nil
+}
+
+// DeploymentStates lists the deployment states Github accepts
+var DeploymentStates = []string{"error", "failure", "inactive", "in_progress", "queued", "pending", "success"}
+
+// checkDeploymentState returns an error if state isn't one of DeploymentStates
+func checkDeploymentState(state string) error {
+	for _, s := range DeploymentStates {
+		if state == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid deployment state %q: must be one of %s", state, strings.Join(DeploymentStates, ", "))
+}
+
+// CreateDeploymentStatus sets the state of a deployment, which must be one
+// of DeploymentStates. description and environmentURL, where the deployed
+// environment can be seen, are optional
+func (c *Client) CreateDeploymentStatus(org, repo string, deploymentID int64, state, description, environmentURL string) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if err := checkDeploymentState(state); err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("set deployment %d in %s/%s to %s", deploymentID, org, repo, state); ok {
+		return nil
+	}
+	status := &github.DeploymentStatusRequest{State: github.String(state)}
+	if description != "" {
+		status.Description = github.String(description)
+	}
+	if environmentURL != "" {
+		status.EnvironmentURL = github.String(environmentURL)
+	}
+	resp, err := c.do("Repositories.CreateDeploymentStatus", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Repositories.CreateDeploymentStatus(ctx, org, repo, deploymentID, status)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Deployment %d not found in repo %s", deploymentID, repo)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when setting deployment status: %w", newAPIError(resp, err))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestListDeployments(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	var gotEnv []string
+	pages := pagesHandler(
+		`[{"id": 2, "environment": "production", "ref": "v1.1.0", "creator": {"login": "deckard"}}]`,
+		`[{"id": 1, "environment": "production", "ref": "v1.0.0", "creator": {"login": "rachael"}}]`,
+	)
+	mux.HandleFunc("/repos/o/r/deployments", func(w http.ResponseWriter, r *http.Request) {
+		gotEnv = append(gotEnv, r.URL.Query().Get("environment"))
+		pages(w, r)
+	})
+
+	got, err := c.ListDeployments("o", "r", "production")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []Deployment{
+		{ID: 2, Environment: "production", Ref: "v1.1.0", Creator: "deckard"},
+		{ID: 1, Environment: "production", Ref: "v1.0.0", Creator: "rachael"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if want := []string{"production", "production"}; !reflect.DeepEqual(gotEnv, want) {
+		t.Errorf("got environments %q, want %q", gotEnv, want)
+	}
+}
+
+func TestGetDeploymentStatus(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/deployments/2/statuses", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"state": "success", "target_url": "https://ci.example.com/2", "creator": {"login": "deckard"}},
+			{"state": "pending", "creator": {"login": "deckard"}}
+		]`)
+	})
+	mux.HandleFunc("/repos/o/r/deployments/3/statuses", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	got, err := c.GetDeploymentStatus("o", "r", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := DeploymentStatus{State: "success", TargetURL: "https://ci.example.com/2", Creator: "deckard"}
+	if *got != want {
+		t.Errorf("got %+v, want %+v", *got, want)
+	}
+
+	if _, err := c.GetDeploymentStatus("o", "r", 3); err == nil {
+		t.Error("expected an error for a deployment with no status, got nil")
+	}
+}
+
+func TestCreateDeploymentStatus(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/deployments/2/statuses", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("got method %s, want POST", r.Method)
+		}
+		var got github.DeploymentStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		want := github.DeploymentStatusRequest{
+			State:          github.String("success"),
+			Description:    github.String("Deployed by deckard"),
+			EnvironmentURL: github.String("https://example.com"),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got request %+v, want %+v", got, want)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"state": "success"}`)
+	})
+
+	if err := c.CreateDeploymentStatus("o", "r", 2, "success", "Deployed by deckard", "https://example.com"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// no handler for deployment 3, so the state must be rejected before any request
+	if err := c.CreateDeploymentStatus("o", "r", 3, "done", "", ""); err == nil || !strings.HasPrefix(err.Error(), "Invalid deployment state") {
+		t.Errorf("got error %v, want an invalid state error", err)
+	}
+}
@@ -0,0 +1,14 @@
+package github
+
+import "fmt"
+
+// dryRun reports whether the Client is in dry run mode. If it is, what the
+// caller would have done is logged and returned as a message for the user.
+func (c *Client) dryRun(format string, args ...interface{}) (string, bool) {
+	if !c.DryRun {
+		return "", false
+	}
+	msg := "Dry run: would have " + fmt.Sprintf(format, args...)
+	c.logger().Infof("%s", msg)
+	return "*" + msg + "*", true
+}
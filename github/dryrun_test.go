@@ -0,0 +1,118 @@
+package github
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// dryRunSetup returns a Client in dry run mode whose server fails the test
+// if it's sent any request
+func dryRunSetup(t *testing.T) (*Client, func()) {
+	c, mux, teardown := setup()
+	c.DryRun = true
+	c.authenticated = true
+	c.Logger = &recordingLogger{}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s in dry run mode", r.Method, r.URL.Path)
+		http.Error(w, `{"message": "Dry run"}`, http.StatusInternalServerError)
+	})
+	return c, teardown
+}
+
+func TestDryRunMessages(t *testing.T) {
+	c, teardown := dryRunSetup(t)
+	defer teardown()
+
+	tests := []struct {
+		name string
+		call func() (string, error)
+		want string
+	}{
+		{"CreateGithubIssue", func() (string, error) {
+			return c.CreateGithubIssue("o", "r", "It's broken")
+		}, `*Dry run: would have created issue "It's broken" in o/r*`},
+		{"CreateRelease", func() (string, error) {
+			return c.CreateRelease("o", "r", ReleaseRequest{TagName: "v1.0.0"})
+		}, "*Dry run: would have created release v1.0.0 in o/r*"},
+		{"MergePullRequest", func() (string, error) {
+			return c.MergePullRequest("o", "r", 7, "squash")
+		}, "*Dry run: would have merged pull request o/r #7 with squash*"},
+		{"CreateBranch", func() (string, error) {
+			return c.CreateBranch("o", "r", "feature", "master")
+		}, "*Dry run: would have created branch feature from master in o/r*"},
+		{"CommentOnIssue", func() (string, error) {
+			return c.CommentOnIssue("o", "r", 3, "hi")
+		}, "*Dry run: would have commented on o/r #3*"},
+		{"CloseIssue", func() (string, error) {
+			return c.CloseIssue("o", "r", 3)
+		}, "*Dry run: would have set o/r #3 to closed*"},
+		{"ForkRepo", func() (string, error) {
+			return c.ForkRepo("o", "r", "bots")
+		}, "*Dry run: would have forked o/r into bots*"},
+		{"ForkRepo to the user", func() (string, error) {
+			return c.ForkRepo("o", "r", "")
+		}, "*Dry run: would have forked o/r into your account*"},
+		{"CreateOrUpdateFile", func() (string, error) {
+			return c.CreateOrUpdateFile("o", "r", "config.yml", "master", "Update config", []byte("new"))
+		}, "*Dry run: would have committed config.yml to master in o/r*"},
+		{"CreateOrUpdateFile to the default branch", func() (string, error) {
+			return c.CreateOrUpdateFile("o", "r", "config.yml", "", "Update config", []byte("new"))
+		}, "*Dry run: would have committed config.yml to the default branch in o/r*"},
+		{"RenameRepo", func() (string, error) {
+			return c.RenameRepo("o", "r", "r2")
+		}, "*Dry run: would have renamed o/r to r2*"},
+	}
+	for _, tt := range tests {
+		got, err := tt.call()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDryRunErrorOnlyMethods(t *testing.T) {
+	c, teardown := dryRunSetup(t)
+	defer teardown()
+
+	calls := map[string]func() error{
+		"DeleteBranch": func() error { return c.DeleteBranch("o", "r", "feature") },
+		"StarRepo":     func() error { return c.StarRepo("o", "r") },
+		"AddLabels":    func() error { return c.AddLabels("o", "r", 3, []string{"bug"}) },
+		"RemoveLabel":  func() error { return c.RemoveLabel("o", "r", 3, "bug") },
+		"SetMilestone": func() error { return c.SetMilestone("o", "r", 3, 1) },
+		"ProtectBranch": func() error {
+			return c.ProtectBranch("o", "r", "master", ProtectionRequest{RequiredReviews: 1})
+		},
+	}
+	for name, call := range calls {
+		if err := call(); err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+	logger := c.Logger.(*recordingLogger)
+	if len(logger.lines) != len(calls) {
+		t.Fatalf("got %d log lines, want %d: %q", len(logger.lines), len(calls), logger.lines)
+	}
+	for _, l := range logger.lines {
+		if !strings.HasPrefix(l, "info: Dry run: would have ") {
+			t.Errorf("unexpected log line %q", l)
+		}
+	}
+}
+
+func TestDryRunStillValidates(t *testing.T) {
+	c, teardown := dryRunSetup(t)
+	defer teardown()
+
+	if _, err := c.MergePullRequest("o", "r", 7, "yolo"); err == nil {
+		t.Error("expected an invalid merge method error")
+	}
+	if _, err := c.CreateRelease("o", "r", ReleaseRequest{}); err == nil {
+		t.Error("expected a missing tag name error")
+	}
+}
@@ -0,0 +1,150 @@
+package github
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// Errors returned when a requested Github resource doesn't exist. They are
+// wrapped with more detail, so compare against them using errors.Is
+var (
+	ErrRepoNotFound      = errors.New("Github repo not found")
+	ErrBranchNotFound    = errors.New("Github branch not found")
+	ErrUserNotFound      = errors.New("Github user not found")
+	ErrGistNotFound      = errors.New("Github gist not found")
+	ErrIssueNotFound     = errors.New("Github issue not found")
+	ErrMilestoneNotFound = errors.New("Github milestone not found")
+	ErrTeamNotFound      = errors.New("Github team not found")
+	ErrNoReleases        = errors.New("Github repo has no releases")
+	ErrTagExists         = errors.New("Github tag already exists")
+	ErrNotMergeable      = errors.New("Github pull request is not mergeable")
+	ErrNoReadme          = errors.New("Github repo has no README")
+	ErrNoLicense         = errors.New("Github repo has no license")
+	ErrIsDirectory       = errors.New("Github path is a directory")
+	ErrCommitRejected    = errors.New("Github rejected the commit")
+	ErrRefNotFound       = errors.New("Github branch or tag not found")
+	ErrCommitNotFound    = errors.New("Github commit not found")
+	ErrBranchExists      = errors.New("Github branch already exists")
+	ErrBranchProtected   = errors.New("Github branch is protected")
+	ErrNotProtected      = errors.New("Github branch is not protected")
+	ErrDefaultBranch     = errors.New("Github branch is the repo's default branch")
+	ErrActionsDisabled   = errors.New("Github Actions is disabled for the repo")
+	ErrCannotRerun       = errors.New("Github Actions run can't be rerun")
+	ErrArtifactExpired   = errors.New("Github Actions artifact has expired")
+	ErrAdminRequired     = errors.New("Github repo admin rights are required")
+	ErrRepoArchived      = errors.New("Github repo is archived or disabled")
+)
+
+// Errors returned by ValidateSignature
+var (
+	ErrMissingSignature   = errors.New("Github webhook signature is missing")
+	ErrMalformedSignature = errors.New("Github webhook signature is malformed")
+	ErrInvalidSignature   = errors.New("Github webhook signature doesn't match")
+)
+
+// ErrAuthRequired is returned by methods that need an API key when the Client
+// was created without one
+var ErrAuthRequired = errors.New("This requires a Github API key")
+
+// ErrAppRequired is returned by methods that only work for a Client from
+// NewAppClient, because Github only lets Apps use them
+var ErrAppRequired = errors.New("This requires a Github App")
+
+// ErrTruncated is returned alongside the results when there were too many
+// to return them all
+var ErrTruncated = errors.New("Too many Github results to return them all")
+
+// ErrOrgNotAllowed is returned when a Client with AllowedOrgs is asked to act
+// on an org that isn't one of them
+var ErrOrgNotAllowed = errors.New("Github org is not allowed")
+
+// APIError is returned when Github responds with an unexpected HTTP status.
+// It wraps the go-github error, if there was one, so it can still be
+// inspected with errors.As
+type APIError struct {
+	StatusCode int
+	Status     string
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	if e.Err == nil {
+		return "Bad response from Github: " + e.Status
+	}
+	return "Bad response from Github: " + e.Status + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying go-github error
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError wraps err in an APIError carrying the status of resp. If there
+// is no response, e.g. the request never reached Github, err is returned as is
+func newAPIError(resp *github.Response, err error) error {
+	if resp == nil || resp.Response == nil {
+		return err
+	}
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Err:        err,
+	}
+}
+
+// UnknownUsersError lists usernames that aren't members of a Github organization
+type UnknownUsersError struct {
+	Org   string
+	Users []string
+}
+
+func (e *UnknownUsersError) Error() string {
+	return "Unknown " + e.Org + " Github usernames: " + strings.Join(e.Users, ", ")
+}
+
+// FileErrors maps the paths GetFiles couldn't fetch to why
+type FileErrors struct {
+	Repo   string
+	Errors map[string]error
+}
+
+func (e *FileErrors) Error() string {
+	paths := make([]string, 0, len(e.Errors))
+	for p := range e.Errors {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	s := make([]string, 0, len(paths))
+	for _, p := range paths {
+		s = append(s, p+": "+e.Errors[p].Error())
+	}
+	return "Could not fetch files from " + e.Repo + ": " + strings.Join(s, "; ")
+}
+
+// hasErrorCode reports whether err is a Github validation error with the given
+// code, e.g. "already_exists". See https://developer.github.com/v3/#client-errors
+func hasErrorCode(err error, code string) bool {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	for _, e := range errResp.Errors {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// errorMessage returns the message Github sent with an error response, or the
+// error itself if it isn't one
+func errorMessage(err error) string {
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Message != "" {
+		return errResp.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestErrBranchNotFound(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "r"}`))
+	})
+	mux.HandleFunc("/repos/o/r/branches/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Branch not found"}`, http.StatusNotFound)
+	})
+
+	err := c.checkRepoAndBranch("o", "r", "missing")
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrBranchNotFound)
+	}
+	if errors.Is(err, ErrRepoNotFound) {
+		t.Errorf("error %v should not match %v", err, ErrRepoNotFound)
+	}
+}
+
+func TestErrRepoNotFound(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	if err := c.checkRepoAndBranch("o", "missing", "master"); !errors.Is(err, ErrRepoNotFound) {
+		t.Errorf("checkRepoAndBranch: got error %v, want %v", err, ErrRepoNotFound)
+	}
+	if _, err := c.CreateGithubIssue("o", "missing", "title"); !errors.Is(err, ErrRepoNotFound) {
+		t.Errorf("CreateGithubIssue: got error %v, want %v", err, ErrRepoNotFound)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/contents/secret.txt", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Forbidden"}`, http.StatusForbidden)
+	})
+
+	_, _, err := c.GetFile("o", "r", "secret.txt", "")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got error %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("got status code %d, want %d", apiErr.StatusCode, http.StatusForbidden)
+	}
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) {
+		t.Errorf("expected the go-github error to be wrapped, got %v", err)
+	}
+}
+
+func TestFileErrors(t *testing.T) {
+	err := &FileErrors{Repo: "r", Errors: map[string]error{
+		"b.txt": errors.New("boom"),
+		"a.txt": errors.New("gone"),
+	}}
+	if got, want := err.Error(), "Could not fetch files from r: a.txt: gone; b.txt: boom"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// The Format functions render the structured results of Client methods as
+// chat messages, in the markdown Slack understands. Frontends that want
+// something else can use the results directly.
+
+// FormatIssueSearch renders the results of SearchIssues for chat
+func FormatIssueSearch(issues []IssueSummary, total int) string {
+	if total == 0 {
+		return "*No issues found*"
+	}
+	s := []string{fmt.Sprintf("*Showing %d of %d matching issues:*", len(issues), total)}
+	for _, i := range issues {
+		s = append(s, fmt.Sprintf("• #%d %s %s", i.Number, i.Title, i.URL))
+	}
+	return strings.Join(s, "\n")
+}
+
+// FormatIssueCreated renders an issue from CreateIssue for chat
+func FormatIssueCreated(issue IssueSummary) string {
+	return fmt.Sprintf("*Issue # %d has been created successfully*\n%s", issue.Number, issue.URL)
+}
+
+// FormatUsernames renders the usernames of org's members from ListMembers
+// for chat
+func FormatUsernames(org string, logins []string) string {
+	s := []string{"*Here's a list of all " + org + " Github usernames:*"}
+	for _, l := range logins {
+		s = append(s, github.Stringify(l))
+	}
+	return strings.Join(s, "\n")
+}
+
+// pullRequestRef matches the "(#123)" Github adds to the subject of a squash
+// merged pull request
+var pullRequestRef = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+
+// FormatReleaseNotes renders the commits between two tags, from
+// CompareCommits, as release notes for chat. Merge commits are left out and
+// commits that reference the same pull request share a bullet, showing the
+// first commit's subject and everyone who worked on it. Authors with a Github
+// login are shown as @handles, others by their git author name
+func FormatReleaseNotes(fromTag, toTag string, commits []CommitSummary) string {
+	type note struct {
+		subject string
+		pr      string
+		authors []string
+	}
+	var notes []*note
+	byPR := make(map[string]*note)
+	for _, commit := range commits {
+		if commit.Merge {
+			continue
+		}
+		subject, pr := commit.Message, ""
+		if m := pullRequestRef.FindStringSubmatch(subject); m != nil {
+			subject, pr = strings.TrimSuffix(subject, m[0]), m[1]
+		}
+		n := byPR[pr]
+		if n == nil {
+			n = &note{subject: subject, pr: pr}
+			notes = append(notes, n)
+			if pr != "" {
+				byPR[pr] = n
+			}
+		}
+		// Only Github logins are handles, an "@" before a git author name
+		// could mention someone else
+		author := commit.Author
+		if commit.AuthorLogin != "" {
+			author = "@" + commit.AuthorLogin
+		}
+		if author != "" && !contains(n.authors, author) {
+			n.authors = append(n.authors, author)
+		}
+	}
+	if len(notes) == 0 {
+		return fmt.Sprintf("*No changes from %s to %s*", fromTag, toTag)
+	}
+	s := []string{fmt.Sprintf("*Changes from %s to %s:*", fromTag, toTag)}
+	for _, n := range notes {
+		line := "• " + n.subject
+		if n.pr != "" {
+			line += " (#" + n.pr + ")"
+		}
+		for _, a := range n.authors {
+			line += " " + a
+		}
+		s = append(s, line)
+	}
+	return strings.Join(s, "\n")
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatIssueSearch(t *testing.T) {
+	issues := []IssueSummary{
+		{Number: 3, Title: "panic", URL: "u3"},
+		{Number: 5, Title: "panic again", URL: "u5"},
+	}
+	if got, want := FormatIssueSearch(issues, 12), "*Showing 2 of 12 matching issues:*\n• #3 panic u3\n• #5 panic again u5"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := FormatIssueSearch(nil, 0), "*No issues found*"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatIssueCreated(t *testing.T) {
+	issue := IssueSummary{Number: 1, Title: "t", URL: "https://github.com/o/r/issues/1"}
+	if got, want := FormatIssueCreated(issue), "*Issue # 1 has been created successfully*\nhttps://github.com/o/r/issues/1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatUsernames(t *testing.T) {
+	got := FormatUsernames("o", []string{"deckard", "rachael"})
+	if want := "*Here's a list of all o Github usernames:*\n\"deckard\"\n\"rachael\""; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := FormatUsernames("o", nil), "*Here's a list of all o Github usernames:*"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatReleaseNotes(t *testing.T) {
+	commits := []CommitSummary{
+		{Author: "deckard", AuthorLogin: "deckard", Message: "Add the Voight-Kampff test (#12)"},
+		{Author: "rachael", AuthorLogin: "rachael", Message: "Fix the test's questions (#12)"},
+		{Author: "deckard", AuthorLogin: "deckard", Message: "Merge branch 'master' into vk", Merge: true},
+		{Author: "gaff", AuthorLogin: "gaff", Message: "Update origami docs"},
+		{Author: "Jane Doe", Message: "Sweep the roof"},
+		{Author: "deckard", AuthorLogin: "deckard", Message: "Merge pull request #13 from o/unicorn", Merge: true},
+		{Author: "gaff", AuthorLogin: "gaff", Message: "Fold a unicorn (#13)"},
+	}
+	got := FormatReleaseNotes("v1.2", "v1.3", commits)
+	want := strings.Join([]string{
+		"*Changes from v1.2 to v1.3:*",
+		"• Add the Voight-Kampff test (#12) @deckard @rachael",
+		"• Update origami docs @gaff",
+		"• Sweep the roof Jane Doe",
+		"• Fold a unicorn (#13) @gaff",
+	}, "\n")
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	merges := []CommitSummary{{Author: "deckard", AuthorLogin: "deckard", Message: "Merge pull request #13 from o/unicorn", Merge: true}}
+	if got, want := FormatReleaseNotes("v1.2", "v1.3", merges), "*No changes from v1.2 to v1.3*"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/github"
+)
+
+// CreateGist creates a gist of files, which maps file names to contents, and
+// returns its URL. Secret gists are only visible to people with the URL
+func (c *Client) CreateGist(description string, files map[string]string, public bool) (string, error) {
+	if len(files) == 0 {
+		return "", errors.New("A gist needs at least one file")
+	}
+	if !c.authenticated {
+		return "", fmt.Errorf("Can't create a gist: %w", ErrAuthRequired)
+	}
+	if msg, ok := c.dryRun("created a gist of %d files", len(files)); ok {
+		return msg, nil
+	}
+	gist := &github.Gist{
+		Description: github.String(description),
+		Public:      github.Bool(public),
+		Files:       make(map[github.GistFilename]github.GistFile, len(files)),
+	}
+	for name, content := range files {
+		gist.Files[github.GistFilename(name)] = github.GistFile{Content: github.String(content)}
+	}
+
+	var created *github.Gist
+	resp, err := c.do("Gists.Create", func(ctx context.Context) (resp *github.Response, err error) {
+		created, resp, err = c.client.Gists.Create(ctx, gist)
+		return
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when creating gist: %w", newAPIError(resp, err))
+	}
+	return created.GetHTMLURL(), nil
+}
+
+// GetGist returns the files of a gist, mapping file names to contents, and
+// its URL. Returns ErrGistNotFound if there's no gist with that ID
+func (c *Client) GetGist(id string) (map[string]string, string, error) {
+	var gist *github.Gist
+	resp, err := c.do("Gists.Get", func(ctx context.Context) (resp *github.Response, err error) {
+		gist, resp, err = c.client.Gists.Get(ctx, id)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("%w: %s", ErrGistNotFound, id)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not fetch gist %s: %w", id, newAPIError(resp, err))
+	}
+	files := make(map[string]string, len(gist.Files))
+	for name, f := range gist.Files {
+		content := f.GetContent()
+		// Github truncates large files, but Size is always the full size
+		if len(content) < f.GetSize() && f.GetRawURL() != "" {
+			content, err = c.getRaw(f.GetRawURL())
+			if err != nil {
+				return nil, "", fmt.Errorf("Could not fetch %s from gist %s: %w", name, id, err)
+			}
+		}
+		files[string(name)] = content
+	}
+	return files, gist.GetHTMLURL(), nil
+}
+
+// getRaw fetches rawURL using the Client's authentication
+func (c *Client) getRaw(rawURL string) (string, error) {
+	req, err := c.client.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	resp, err := c.do("Gists.GetRaw", func(ctx context.Context) (*github.Response, error) {
+		buf.Reset()
+		return c.client.Do(ctx, req, &buf)
+	})
+	if err != nil {
+		return "", newAPIError(resp, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCreateGist(t *testing.T) {
+	for _, public := range []bool{true, false} {
+		t.Run(fmt.Sprintf("public %t", public), func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			c.authenticated = true
+
+			mux.HandleFunc("/gists", func(w http.ResponseWriter, r *http.Request) {
+				var got github.Gist
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				want := github.Gist{
+					Description: github.String("A snippet"),
+					Public:      github.Bool(public),
+					Files: map[github.GistFilename]github.GistFile{
+						"main.go": {Content: github.String("package main")},
+					},
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("got request %+v, want %+v", got, want)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id": "aa5a315d", "html_url": "https://gist.github.com/aa5a315d"}`)
+			})
+
+			got, err := c.CreateGist("A snippet", map[string]string{"main.go": "package main"}, public)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := "https://gist.github.com/aa5a315d"; got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCreateGistNoFiles(t *testing.T) {
+	c, _, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	if _, err := c.CreateGist("Empty", nil, true); err == nil {
+		t.Error("expected an error for a gist without files, got nil")
+	}
+}
+
+func TestGetGist(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/gists/aa5a315d", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"id": "aa5a315d",
+			"html_url": "https://gist.github.com/aa5a315d",
+			"files": {
+				"small.txt": {"filename": "small.txt", "size": 5, "content": "hello"},
+				"big.txt": {"filename": "big.txt", "size": 11, "truncated": true, "content": "hello", "raw_url": "%s/raw/big.txt"}
+			}
+		}`, "http://"+r.Host)
+	})
+	mux.HandleFunc("/raw/big.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello world")
+	})
+	mux.HandleFunc("/gists/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	files, htmlURL, err := c.GetGist("aa5a315d")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]string{"small.txt": "hello", "big.txt": "hello world"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("got files %v, want %v", files, want)
+	}
+	if want := "https://gist.github.com/aa5a315d"; htmlURL != want {
+		t.Errorf("got URL %q, want %q", htmlURL, want)
+	}
+
+	if _, _, err := c.GetGist("missing"); !errors.Is(err, ErrGistNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrGistNotFound)
+	}
+}
@@ -2,57 +2,271 @@
 package github
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"strings"
-
-	"github.com/handwritingio/deckard-bot/log"
+	"time"
 
 	"github.com/google/go-github/github"
-	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 )
 
 // Client is a wrapper for the github Client
 type Client struct {
-	client *github.Client
+	// Org is the default organization for methods that search across
+	// repositories, such as SearchCode
+	Org string
+
+	// MaxRetries is how many times a request that hits a Github rate limit
+	// is retried before the error is returned. Zero disables retries.
+	MaxRetries int
+	// MaxRetryWait caps how long the Client will wait in total while
+	// retrying a request. If Github asks for a wait that would go past it
+	// the rate limit error is returned instead.
+	MaxRetryWait time.Duration
+	// CallTimeout is the deadline given to each Github API call whose
+	// context doesn't already have one, so a stuck call can't hang the bot.
+	// Deadlines set with WithContext are always used instead. Zero means no
+	// deadline. It applies to each attempt, like the HTTP client's timeout
+	// (see WithTimeout), and whichever is shorter ends the call; only the
+	// HTTP timeout limits bodies read after a call returns, like downloads.
+	CallTimeout time.Duration
+	// CacheTTL is how long the Client remembers whether a repo or branch
+	// exists, saving API calls when the same repo is used repeatedly. Zero
+	// disables the cache.
+	CacheTTL time.Duration
+	// PerPage is how many results list methods ask Github for at a time.
+	// Github allows at most 100, and uses 30 if it's zero.
+	PerPage int
+	// MaxDiffBytes is the longest diff GetPullRequestDiff returns, to keep it
+	// short enough for chat. Zero means no limit.
+	MaxDiffBytes int
+	// Metrics, if set, is told about every Github API call the Client makes
+	Metrics Metrics
+	// Logger is where the Client logs to. It defaults to the log package.
+	Logger Logger
+	// AllowedOrgs, if set, are the only orgs the Client will act on. Other
+	// orgs get ErrOrgNotAllowed without an API call being made.
+	AllowedOrgs []string
+	// DryRun stops the Client from changing anything on Github. Methods
+	// that would make changes return a message saying what they would have
+	// done instead, without making any API calls.
+	DryRun bool
+
+	cache         *existsCache
+	client        *github.Client
+	httpClient    *http.Client
+	ctx           context.Context
+	authenticated bool
+	// app is set for Clients authenticated as a Github App installation
+	app bool
+	// sleep waits between retries, it's replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
-const archiveFormat = github.Tarball
+// DefaultPerPage is the page size used by list methods of a Client from
+// NewClient. It's the most Github allows, to keep round trips down
+const DefaultPerPage = 100
+
+// Archive formats accepted by GetArchive
+const (
+	ArchiveTar = "tar"
+	ArchiveZip = "zip"
+)
+
+// DefaultTimeout is how long a Client from NewClient waits for a Github
+// response before giving up
+const DefaultTimeout = 30 * time.Second
 
-// Support for go-github package addition of contact
-// https://github.com/google/go-github/issues/526
-var ctx = context.Background()
+// Option configures a Client when it's created
+type Option func(*Client)
+
+// WithTimeout sets how long the Client waits for each HTTP request to Github,
+// including reading the response body, so it also limits archive downloads.
+// Zero means no timeout
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
 
 // NewClient creates a new Client including authentication
-func NewClient(apiKey string) *Client {
-	if apiKey == "" {
-		// return a non-authenticated client if an API key isn't set,
-		// (so client can still access public resources)
-		return &Client{client: github.NewClient(nil)}
-	}
-	// return an authenticated client
-	// https://github.com/google/go-github#authentication
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: apiKey},
-	)
-	tc := oauth2.NewClient(oauth2.NoContext, ts)
+func NewClient(apiKey string, opts ...Option) *Client {
+	httpClient := newHTTPClient(apiKey)
+	c := newClient(github.NewClient(httpClient), httpClient, apiKey != "")
+	c.apply(opts)
+	return c
+}
+
+// NewClientWithHTTP creates a new Client that sends its requests through
+// httpClient, e.g. one set up with a proxy or custom TLS. If apiKey is set,
+// authentication is added on top of httpClient's transport. httpClient itself
+// isn't changed, and its Timeout is used in place of DefaultTimeout
+func NewClientWithHTTP(apiKey string, httpClient *http.Client, opts ...Option) *Client {
+	if httpClient == nil {
+		return NewClient(apiKey, opts...)
+	}
+	httpClient = withAPIKey(httpClient, apiKey)
+	c := newClient(github.NewClient(httpClient), httpClient, apiKey != "")
+	c.apply(opts)
+	return c
+}
+
+// NewEnterpriseClient creates a new Client that talks to a Github Enterprise
+// instance at baseURL, e.g. https://github.example.com/api/v3/. uploadURL is
+// often the same as baseURL. An error is returned if either URL is invalid.
+func NewEnterpriseClient(apiKey, baseURL, uploadURL string, opts ...Option) (*Client, error) {
+	for _, u := range []string{baseURL, uploadURL} {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid Github Enterprise URL %q: %s", u, err.Error())
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("Invalid Github Enterprise URL %q: scheme and host are required", u)
+		}
+	}
+	httpClient := newHTTPClient(apiKey)
+	client, err := github.NewEnterpriseClient(baseURL, uploadURL, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	c := newClient(client, httpClient, apiKey != "")
+	c.apply(opts)
+	return c, nil
+}
+
+// newClient wraps client, which sends its requests with httpClient, with the
+// default retry and cache settings
+func newClient(client *github.Client, httpClient *http.Client, authenticated bool) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
 	return &Client{
-		client: github.NewClient(tc),
+		MaxRetries:    DefaultMaxRetries,
+		MaxRetryWait:  DefaultMaxRetryWait,
+		CallTimeout:   DefaultCallTimeout,
+		CacheTTL:      DefaultCacheTTL,
+		PerPage:       DefaultPerPage,
+		MaxDiffBytes:  DefaultMaxDiffBytes,
+		Logger:        defaultLogger{},
+		cache:         newExistsCache(),
+		client:        client,
+		httpClient:    httpClient,
+		authenticated: authenticated,
+		sleep:         sleepContext,
+	}
+}
+
+// newHTTPClient returns an http.Client with DefaultTimeout that authenticates
+// with apiKey, or a non-authenticated one if apiKey isn't set. It's never
+// http.DefaultClient, so options can change it
+func newHTTPClient(apiKey string) *http.Client {
+	return withAPIKey(&http.Client{Timeout: DefaultTimeout}, apiKey)
+}
+
+// withAPIKey returns a copy of httpClient that authenticates with apiKey
+// https://github.com/google/go-github#authentication
+func withAPIKey(httpClient *http.Client, apiKey string) *http.Client {
+	authed := *httpClient
+	if apiKey == "" {
+		// a non-authenticated client can still access public resources
+		return &authed
+	}
+	// the oauth2 transport goes inside the http.Client, so the client's
+	// timeout covers the whole request
+	authed.Transport = &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiKey}),
+		Base:   httpClient.Transport,
+	}
+	return &authed
+}
+
+// apply configures the Client with opts
+func (c *Client) apply(opts []Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithContext returns a shallow copy of the Client whose requests use ctx.
+// Use this to set a deadline on, or cancel, slow Github calls:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	contents, _, err := client.WithContext(ctx).GetFile(org, repo, path, "")
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// requestContext returns the Client's context, defaulting to context.Background
+func (c *Client) requestContext() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+// allowOrg returns ErrOrgNotAllowed if the Client has AllowedOrgs and org
+// isn't one of them. Github org names aren't case sensitive
+func (c *Client) allowOrg(org string) error {
+	if len(c.AllowedOrgs) == 0 {
+		return nil
 	}
+	for _, allowed := range c.AllowedOrgs {
+		if strings.EqualFold(allowed, org) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrOrgNotAllowed, org)
 }
 
 // GetFile returns the contents of a file and the download URL of the file
 // from a file within a github repository. A repository and path to a file must be supplied.
-func (c *Client) GetFile(org, repo, path string) ([]byte, string, error) {
-	opt := &github.RepositoryContentGetOptions{}
-	content, _, resp, err := c.client.Repositories.GetContents(ctx, org, repo, path, opt)
-	if resp.StatusCode != 200 {
-		return nil, "", errors.New("Bad response from Github: " + resp.Status)
+// ref is the branch, tag or commit SHA to read from, or empty for the repo's default branch.
+// Files of any size Github stores are returned in full.
+func (c *Client) GetFile(org, repo, path, ref string) ([]byte, string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, "", err
 	}
+	opt := &github.RepositoryContentGetOptions{Ref: ref}
+	var content *github.RepositoryContent
+	var directory []*github.RepositoryContent
+	resp, err := c.do("Repositories.GetContents", func(ctx context.Context) (resp *github.Response, err error) {
+		content, directory, resp, err = c.client.Repositories.GetContents(ctx, org, repo, path, opt)
+		return
+	})
 	if err != nil {
-		return nil, "", err
+		return nil, "", newAPIError(resp, err)
+	}
+	// resp is nil when the request fails before Github responds, and
+	// go-github doesn't promise a response even when err is nil
+	if resp == nil {
+		return nil, "", errors.New("No response from Github")
+	}
+	if resp.StatusCode != 200 {
+		return nil, "", newAPIError(resp, nil)
+	}
+	// go-github returns a listing instead of content for directories
+	if content == nil && directory != nil {
+		return nil, "", fmt.Errorf("%w: %s, use ListContents instead", ErrIsDirectory, path)
+	}
+	// Github leaves out the content of files over 1MB, so they're fetched
+	// as a blob instead, which works up to 100MB
+	if content.GetEncoding() == "none" || (content.Content == nil && content.GetSize() > 0) {
+		blob, err := c.getBlob(org, repo, content.GetSHA())
+		if err != nil {
+			return nil, "", err
+		}
+		return blob, content.GetDownloadURL(), nil
 	}
 	decoded, err := content.GetContent()
 	if err != nil {
@@ -61,181 +275,450 @@ func (c *Client) GetFile(org, repo, path string) ([]byte, string, error) {
 	return []byte(decoded), content.GetDownloadURL(), nil
 }
 
+// getBlob returns the raw contents of the blob with sha
+func (c *Client) getBlob(org, repo, sha string) ([]byte, error) {
+	var blob []byte
+	resp, err := c.do("Git.GetBlobRaw", func(ctx context.Context) (resp *github.Response, err error) {
+		blob, resp, err = c.client.Git.GetBlobRaw(ctx, org, repo, sha)
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch blob %s in %s: %w", sha, repo, newAPIError(resp, err))
+	}
+	return blob, nil
+}
+
+// GetReadme returns the decoded README of a repo, its HTML URL and its size
+// in bytes, so callers can truncate long READMEs. Returns ErrNoReadme if the
+// repo doesn't have one
+func (c *Client) GetReadme(org, repo string) (string, string, int, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", "", 0, err
+	}
+	var readme *github.RepositoryContent
+	resp, err := c.do("Repositories.GetReadme", func(ctx context.Context) (resp *github.Response, err error) {
+		readme, resp, err = c.client.Repositories.GetReadme(ctx, org, repo, nil)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", "", 0, fmt.Errorf("%w: %s", ErrNoReadme, repo)
+	}
+	if err != nil {
+		return "", "", 0, fmt.Errorf("Could not fetch README for %s: %w", repo, newAPIError(resp, err))
+	}
+	decoded, err := readme.GetContent()
+	if err != nil {
+		return "", "", 0, err
+	}
+	return decoded, readme.GetHTMLURL(), readme.GetSize(), nil
+}
+
+// Rate is the API quota for one category of requests
+type Rate struct {
+	Limit     int
+	Remaining int
+	// Reset is when Remaining goes back up to Limit
+	Reset time.Time
+}
+
+// RateLimits holds the API quotas for core requests and searches, which
+// Github limits separately
+type RateLimits struct {
+	Core   Rate
+	Search Rate
+}
+
+// RateLimit returns the Client's current API rate limits
+func (c *Client) RateLimit() (*RateLimits, error) {
+	var limits *github.RateLimits
+	resp, err := c.do("RateLimits", func(ctx context.Context) (resp *github.Response, err error) {
+		limits, resp, err = c.client.RateLimits(ctx)
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch Github rate limit: %w", newAPIError(resp, err))
+	}
+	return &RateLimits{
+		Core:   newRate(limits.GetCore()),
+		Search: newRate(limits.GetSearch()),
+	}, nil
+}
+
+func newRate(r *github.Rate) Rate {
+	if r == nil {
+		return Rate{}
+	}
+	return Rate{Limit: r.Limit, Remaining: r.Remaining, Reset: r.Reset.Time}
+}
+
 // CheckGithubRateLimit returns the API Rate limit to the debug console
 // https://github.com/google/go-github/blob/master/examples/repos/main.go
 func (c *Client) CheckGithubRateLimit() {
-	rate, _, err := c.client.RateLimits(ctx)
+	rate, err := c.RateLimit()
 	if err != nil {
-		log.Debugf("Error fetching Github rate limit: %#v\n", err)
+		c.logger().Debugf("Error fetching Github rate limit: %#v", err)
 	} else {
-		log.Debugf("Github API Rate Limit: %#v\n", rate)
+		c.logger().Debugf("Github API Rate Limit: %#v", rate)
 	}
 }
 
 // checkGithubRepo takes a repo as a string you'd like to check
-// and confirms whether or not the repo exists and the Client has access to it
-func (c *Client) checkGithubRepo(org, repo string) bool {
-	opt := &github.RepositoryListByOrgOptions{
-		ListOptions: github.ListOptions{PerPage: 10},
-	}
-	// get all pages of results
-	// https://godoc.org/github.com/google/go-github/github#hdr-Pagination
-	var allRepos []*github.Repository
-	for {
-		repos, resp, err := c.client.Repositories.ListByOrg(ctx, org, opt)
-		if err != nil {
-			log.Error(err)
-			break
-		}
-		allRepos = append(allRepos, repos...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.ListOptions.Page = resp.NextPage
+// and confirms whether or not the repo exists and the Client has access to it.
+// A 404 from Github means the repo wasn't found, any other failure is returned.
+// Results are cached for CacheTTL.
+func (c *Client) checkGithubRepo(org, repo string) (bool, error) {
+	key := repoCacheKey(org, repo)
+	if found, ok := c.cache.get(key); ok {
+		return found, nil
 	}
-	for _, r := range allRepos {
-		log.Printf("r.Name: %s\n", *r.Name)
-		if *r.Name == repo {
-			return true
+	_, err := c.Repo(org, repo).Get()
+	if errors.Is(err, ErrRepoNotFound) {
+		c.cache.set(key, false, c.CacheTTL)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	c.cache.set(key, true, c.CacheTTL)
+	return true, nil
+}
+
+// CheckRepoAccess reports whether a repo exists and whether the Client can
+// read it. Github answers with a 404 both for repos that don't exist and for
+// private repos the Client can't see, so those both report exists as false;
+// only a repo Github admits to but refuses, e.g. one blocked for legal
+// reasons, is reported as existing but not accessible. Other failures are
+// returned as errors
+func (c *Client) CheckRepoAccess(org, repo string) (exists bool, accessible bool, err error) {
+	if err := c.allowOrg(org); err != nil {
+		return false, false, err
+	}
+	resp, err := c.do("Repositories.Get", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Repositories.Get(ctx, org, repo)
+		return
+	})
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return false, false, nil
+		case http.StatusForbidden, http.StatusUnavailableForLegalReasons:
+			if _, ok := retryWait(resp, err); !ok {
+				return true, false, nil
+			}
 		}
 	}
-	return false
+	if err != nil {
+		return false, false, fmt.Errorf("Could not check access to %s: %w", repo, newAPIError(resp, err))
+	}
+	return true, true, nil
 }
 
-// GetArchive returns an Archive based on the repo and branch supplied
-func (c *Client) GetArchive(org, repo, branch string) (*url.URL, string, error) {
+// GetArchive returns an Archive based on the repo and branch supplied. An
+// empty branch means the repo's default branch. format is ArchiveTar or
+// ArchiveZip, and defaults to ArchiveTar if empty
+func (c *Client) GetArchive(org, repo, branch, format string) (*url.URL, string, error) {
+	if format == "" {
+		format = ArchiveTar
+	}
+	if format != ArchiveTar && format != ArchiveZip {
+		return nil, "", fmt.Errorf("Unknown archive format %q, expected %s or %s", format, ArchiveTar, ArchiveZip)
+	}
+	if branch == "" {
+		var err error
+		if branch, err = c.GetDefaultBranch(org, repo); err != nil {
+			return nil, "", err
+		}
+	}
 	err := c.checkRepoAndBranch(org, repo, branch)
 	if err != nil {
 		return nil, "", err
 	}
-	return c.getArchive(org, repo, branch)
+	return c.getArchive(org, repo, branch, format)
 }
 
-func (c *Client) getArchive(org, repo, branch string) (*url.URL, string, error) {
-	opts := github.RepositoryContentGetOptions{
-		Ref: branch,
+// DownloadArchive returns a tarball of branch and the commit SHA it was made
+// from. The archive is fetched with the Client's authentication, so it works
+// for private repos. The caller must close the returned reader
+func (c *Client) DownloadArchive(org, repo, branch string) (io.ReadCloser, string, error) {
+	archiveURL, sha, err := c.GetArchive(org, repo, branch, ArchiveTar)
+	if err != nil {
+		return nil, "", err
 	}
-	archiveURL, _, err := c.client.Repositories.GetArchiveLink(ctx, org, repo, archiveFormat, &opts)
+	req, err := http.NewRequest("GET", archiveURL.String(), nil)
 	if err != nil {
-		log.Errorf("Could not get archive URL: %s", err.Error())
 		return nil, "", err
 	}
-	b, _, err := c.client.Repositories.GetBranch(ctx, org, repo, branch)
+	resp, err := c.httpClient.Do(req.WithContext(c.requestContext()))
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not download archive of %s: %w", repo, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("Could not download archive of %s: %w", repo, newAPIError(&github.Response{Response: resp}, nil))
+	}
+	return resp.Body, sha, nil
+}
+
+func (c *Client) getArchive(org, repo, branch, format string) (*url.URL, string, error) {
+	// the archive is of the resolved commit, so it can't differ from the
+	// SHA returned if the branch moves in between
+	sha, err := c.ResolveRef(org, repo, branch)
 	if err != nil {
 		return nil, "", err
 	}
-	commit := *b.Commit.SHA
-	return archiveURL, commit, nil
+	opts := github.RepositoryContentGetOptions{
+		Ref: sha,
+	}
+	archiveFormat := github.Tarball
+	if format == ArchiveZip {
+		archiveFormat = github.Zipball
+	}
+	var archiveURL *url.URL
+	_, err = c.do("Repositories.GetArchiveLink", func(ctx context.Context) (resp *github.Response, err error) {
+		archiveURL, resp, err = c.client.Repositories.GetArchiveLink(ctx, org, repo, archiveFormat, &opts)
+		return
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return archiveURL, sha, nil
 }
 
-// CheckBranch checks if the repo supplied exists and the branch exists for the
-// supplied repo. Returns a boolean
+// checkRepoAndBranch checks if the repo supplied exists and the branch exists for the
+// supplied repo. Returns ErrRepoNotFound or ErrBranchNotFound if either is missing
 func (c *Client) checkRepoAndBranch(org, repo, branch string) error {
-	if !c.checkGithubRepo(org, repo) {
-		return fmt.Errorf("Github repo not found: %s", repo)
-	}
-	opt := &github.ListOptions{
-		PerPage: 10,
+	found, err := c.checkGithubRepo(org, repo)
+	if err != nil {
+		return fmt.Errorf("Could not check Github repo %s: %w", repo, err)
 	}
-	// Page all branches
-	var allBranches []*github.Branch
-	for {
-		branches, resp, err := c.client.Repositories.ListBranches(ctx, org, repo, opt)
-		if err != nil {
-			return fmt.Errorf("Could not fetch branches for %s: %s", repo, err.Error())
-		}
-		allBranches = append(allBranches, branches...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.Page = resp.NextPage
+	if !found {
+		return fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
 	}
+	return c.checkBranch(org, repo, branch)
+}
 
-	for _, b := range allBranches {
-		if branch == *b.Name {
-			return nil
+// checkBranch checks if the branch exists in a repo that's known to exist.
+// Returns ErrBranchNotFound if it doesn't. Results are cached for CacheTTL.
+func (c *Client) checkBranch(org, repo, branch string) error {
+	key := branchCacheKey(org, repo, branch)
+	if found, ok := c.cache.get(key); ok {
+		if !found {
+			return fmt.Errorf("%w: %s in repo %s", ErrBranchNotFound, branch, repo)
 		}
+		return nil
+	}
+	_, err := c.Repo(org, repo).Branch(branch)
+	if err != nil && !errors.Is(err, ErrBranchNotFound) {
+		return err
 	}
-	return fmt.Errorf("No branch named %s found in repo %s", branch, repo)
+	c.cache.set(key, err == nil, c.CacheTTL)
+	return err
 }
 
 // GetGithubUsers returns the usernames for all users in the github organization
 // This can then be used in the assignee section of !git issue. This is useful if you don't
 // know the github username of the person you'd like to assign the issue to.
-func (c *Client) GetGithubUsers(org string) (out string) {
-	// Get Org members
-	opt := &github.ListMembersOptions{
-		ListOptions: github.ListOptions{PerPage: 10},
+func (c *Client) GetGithubUsers(org string) (string, error) {
+	logins, err := c.ListMembers(org)
+	if err != nil {
+		return "", err
 	}
-	var allUsers []*github.User
-	for {
-		users, resp, err := c.client.Organizations.ListMembers(ctx, org, opt)
-		if err != nil {
-			out = fmt.Sprintf("Could not fetch users for %s: %s", org, err.Error())
-			return
+	return FormatUsernames(org, logins), nil
+}
+
+// ListMembers returns the usernames of all members of the github organization
+func (c *Client) ListMembers(org string) ([]string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	members, err := c.listOrgMembers(org)
+	if err != nil {
+		return nil, err
+	}
+	logins := make([]string, 0, len(members))
+	for _, m := range members {
+		logins = append(logins, m.GetLogin())
+	}
+	return logins, nil
+}
+
+// orgMembers returns the users that are members of org, and an
+// *UnknownUsersError listing any that aren't. Logins aren't case sensitive
+func (c *Client) orgMembers(org string, users []string) (valid []string, unknownErr error, err error) {
+	members, err := c.listOrgMembers(org)
+	if err != nil {
+		return nil, nil, err
+	}
+	known := make(map[string]bool)
+	for _, m := range members {
+		known[strings.ToLower(m.GetLogin())] = true
+	}
+	var unknown []string
+	for _, u := range users {
+		if known[strings.ToLower(u)] {
+			valid = append(valid, u)
+		} else {
+			unknown = append(unknown, u)
 		}
-		if resp.StatusCode != 200 {
-			out = "Bad responds from Github: " + resp.Status
+	}
+	if len(unknown) > 0 {
+		unknownErr = &UnknownUsersError{Org: org, Users: unknown}
+	}
+	return valid, unknownErr, nil
+}
+
+// listOrgMembers pages through all members of the github organization
+func (c *Client) listOrgMembers(org string) ([]*github.User, error) {
+	opt := &github.ListMembersOptions{
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	users, resp, err := paginate(&opt.ListOptions, func() ([]*github.User, *github.Response, error) {
+		var users []*github.User
+		resp, err := c.do("Organizations.ListMembers", func(ctx context.Context) (resp *github.Response, err error) {
+			users, resp, err = c.client.Organizations.ListMembers(ctx, org, opt)
 			return
+		})
+		if err == nil && resp.StatusCode != 200 {
+			err = errors.New(resp.Status)
 		}
-		allUsers = append(allUsers, users...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.ListOptions.Page = resp.NextPage
+		return users, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch users for %s: %w", org, newAPIError(resp, err))
 	}
+	return users, nil
+}
+
+// IssueOptions holds the optional fields used when creating an issue
+type IssueOptions struct {
+	// Body defaults to defaultIssueBody when empty
+	Body      string
+	Labels    []string
+	Assignees []string
+}
 
-	s := []string{"*Here's a list of all " + org + " Github usernames:*"}
+const defaultIssueBody = "Issue created by the Deckard Chatbot Plugin"
+
+// CreateGithubIssue creates issues in github for the supplied repo
+// and returns a message with a link to the new issue
+func (c *Client) CreateGithubIssue(org, repo, issue string) (string, error) {
+	return c.CreateGithubIssueWithOptions(org, repo, issue, IssueOptions{})
+}
 
-	for _, r := range allUsers {
-		githubUsername := github.Stringify(r.Login)
-		log.Debug("Github Username: " + githubUsername)
-		s = append(s, githubUsername)
+// CreateGithubIssueWithOptions creates an issue like CreateGithubIssue, also
+// setting the body, labels and assignees supplied in opts
+func (c *Client) CreateGithubIssueWithOptions(org, repo, issue string, opts IssueOptions) (string, error) {
+	created, dryRunMsg, err := c.createIssue(org, repo, issue, opts)
+	if err != nil {
+		return "", err
+	}
+	if dryRunMsg != "" {
+		return dryRunMsg, nil
 	}
-	out = strings.Join(s, "\n")
-	return
+	return FormatIssueCreated(created), nil
 }
 
-// CreateGithubIssue creates issues in github for the supplied repo
-func (c *Client) CreateGithubIssue(org, repo, issue string) (out string) {
+// CreateIssue creates an issue titled title in the supplied repo, with the
+// body, labels and assignees in opts, and returns it. In dry run mode nothing
+// is created and the returned issue only has its Title set
+func (c *Client) CreateIssue(org, repo, title string, opts IssueOptions) (IssueSummary, error) {
+	created, _, err := c.createIssue(org, repo, title, opts)
+	return created, err
+}
 
-	// Check if repo exists
-	if !c.checkGithubRepo(org, repo) {
-		out = "PANIC: `" + repo + "` Repository Does Not Exist"
-		return
+// createIssue is CreateIssue, also returning the dry run message when in dry
+// run mode
+func (c *Client) createIssue(org, repo, title string, opts IssueOptions) (IssueSummary, string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return IssueSummary{}, "", err
+	}
+	if msg, ok := c.dryRun("created issue %q in %s/%s", title, org, repo); ok {
+		return IssueSummary{Title: title}, msg, nil
+	}
+
+	// Check the repo exists and isn't archived
+	if _, err := c.Repo(org, repo).writable(); err != nil {
+		return IssueSummary{}, "", err
 	}
 
-	// Creates issueRequest message based on supplied issue string
+	// Creates issueRequest message based on supplied title
+	body := opts.Body
+	if body == "" {
+		body = defaultIssueBody
+	}
 	issueMsg := github.IssueRequest{
-		Title: github.String(issue),
-		Body:  github.String("Issue created by the Deckard Chatbot Plugin"),
+		Title: github.String(title),
+		Body:  github.String(body),
+	}
+	if len(opts.Labels) > 0 {
+		issueMsg.Labels = &opts.Labels
+	}
+	if len(opts.Assignees) > 0 {
+		issueMsg.Assignees = &opts.Assignees
 	}
 	// Create issue
-	i, resp, err := c.client.Issues.Create(ctx, org, repo, &issueMsg)
-	if err != nil {
-		out = fmt.Sprintf("Error occurred when creating issue: %s", err.Error())
+	var i *github.Issue
+	resp, err := c.do("Issues.Create", func(ctx context.Context) (resp *github.Response, err error) {
+		i, resp, err = c.client.Issues.Create(ctx, org, repo, &issueMsg)
 		return
+	})
+	if err != nil {
+		return IssueSummary{}, "", fmt.Errorf("Error occurred when creating issue: %w", newAPIError(resp, err))
 	}
 	// Check returned status code
-	issueStatusCode := resp.StatusCode
-	if issueStatusCode != 201 {
-		out = "PANIC: Issue was not created!\nResponse code: " + resp.Status
-		return
+	if resp.StatusCode != 201 {
+		return IssueSummary{}, "", fmt.Errorf("Issue was not created: %w", newAPIError(resp, nil))
 	}
-	issueNumber := *i.Number
-	issueURL := *i.HTMLURL
-	log.Debugf("Issue URL: %s", issueURL)
-	log.Debugf("Issue number: %d", issueNumber)
-	log.Debugf("Create issue status code: %d", issueStatusCode)
-
-	out = fmt.Sprintf("*Issue # %d has been created successfully*\n%s", issueNumber, issueURL)
+	c.logger().Debugf("Created issue %s #%d: %s", repo, i.GetNumber(), i.GetHTMLURL())
 
-	return
+	return IssueSummary{
+		Number:   i.GetNumber(),
+		Title:    i.GetTitle(),
+		URL:      i.GetHTMLURL(),
+		Assignee: i.GetAssignee().GetLogin(),
+	}, "", nil
 }
 
+// fallbackOctocat is shown by Octocat when Github can't be reached
+const fallbackOctocat = `
+               MMM.           .MMM
+               MMMMMMMMMMMMMMMMMMM
+               MMMMMMMMMMMMMMMMMMM      %s
+              MMMMMMMMMMMMMMMMMMMMM
+             MMMMMMMMMMMMMMMMMMMMMMM
+            MMMMMMMMMMMMMMMMMMMMMMMM
+            MMMM::- -:::::::- -::MMMM
+             MM~:~ 00~:::::~ 00~:~MM
+        .. MMMMM::.00:::+:::.00::MMMMM ..
+              .MM::::: ._. :::::MM.
+                 MMMM;:::::;MMMM
+          -MM        MMMMMMM
+          ^  M+     MMMMMMMMM
+              MMMMMMM MM MM MM
+                   MM MM MM MM
+                   MM MM MM MM
+                .~~MM~MM~MM~MM~~.
+             ~~~~MM:~MM~~~MM~:MM~~~~
+            ~~~~~~==~==~~~==~==~~~~~~
+             ~~~~~~==~==~==~==~~~~~~
+                 :~==~==~==~==~~
+`
+
 // Octocat is a wrapper around github Client octocat
-// prints an ASCII octocat
-func (c *Client) Octocat(message string) string {
-	octocat, _, _ := c.client.Octocat(ctx, message)
-	return octocat
+// prints an ASCII octocat. If Github can't be reached a local octocat is
+// returned instead, an error is only returned if Github's response is empty
+func (c *Client) Octocat(message string) (string, error) {
+	var octocat string
+	_, err := c.do("Octocat", func(ctx context.Context) (resp *github.Response, err error) {
+		octocat, resp, err = c.client.Octocat(ctx, message)
+		return
+	})
+	if err != nil {
+		c.logger().Warnf("Could not fetch octocat, using the local one: %s", err.Error())
+		return fmt.Sprintf(fallbackOctocat, message), nil
+	}
+	if octocat == "" {
+		return "", errors.New("Github returned an empty octocat")
+	}
+	return octocat, nil
 }
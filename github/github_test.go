@@ -0,0 +1,875 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// setup starts a test HTTP server and returns a Client pointed at it along
+// with the mux used to register handlers. teardown must be called when the
+// test is done.
+func setup() (c *Client, mux *http.ServeMux, teardown func()) {
+	mux = http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	c = NewClient("")
+	u, _ := url.Parse(server.URL + "/")
+	c.client.BaseURL = u
+
+	return c, mux, server.Close
+}
+
+// pagesHandler serves each of pages in turn, setting the Link header so
+// go-github follows them to the next page
+func pagesHandler(pages ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		if page > len(pages) {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		if page < len(pages) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d>; rel="next"`, r.URL.Path, page+1))
+		}
+		fmt.Fprint(w, pages[page-1])
+	}
+}
+
+func TestGetFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name: "file found",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{
+					"type": "file",
+					"encoding": "base64",
+					"content": "aGVsbG8=",
+					"download_url": "https://raw.example.com/README.md"
+				}`)
+			},
+			wantBody: "hello",
+		},
+		{
+			name: "not found",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection closed before response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Fatal(err)
+				}
+				conn.Close()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			mux.HandleFunc("/repos/o/r/contents/README.md", tt.handler)
+
+			body, _, err := c.GetFile("o", "r", "README.md", "")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestGetFileOverContentsLimit(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	big := strings.Repeat("0123456789abcdef", 1<<17) // 2MB
+	mux.HandleFunc("/repos/o/r/contents/data.bin", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"type": "file",
+			"encoding": "none",
+			"content": "",
+			"size": %d,
+			"sha": "3d21ec53a331a6f037a91c368710b99387d012c1",
+			"download_url": "https://raw.example.com/data.bin"
+		}`, len(big))
+	})
+	mux.HandleFunc("/repos/o/r/git/blobs/3d21ec53a331a6f037a91c368710b99387d012c1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/vnd.github.v3.raw" {
+			t.Errorf("got Accept %q, want the raw media type", got)
+		}
+		fmt.Fprint(w, big)
+	})
+
+	body, downloadURL, err := c.GetFile("o", "r", "data.bin", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(body) != big {
+		t.Errorf("got %d bytes, want all %d", len(body), len(big))
+	}
+	if want := "https://raw.example.com/data.bin"; downloadURL != want {
+		t.Errorf("got download URL %q, want %q", downloadURL, want)
+	}
+}
+
+func TestGetFileAtRef(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		content := "aGVsbG8=" // hello
+		if r.URL.Query().Get("ref") == "feature" {
+			content = "ZmVhdHVyZQ==" // feature
+		}
+		fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "content": %q}`, content)
+	})
+
+	body, _, err := c.GetFile("o", "r", "README.md", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(body) != "feature" {
+		t.Errorf("got body %q, want %q", body, "feature")
+	}
+}
+
+func TestGetReadme(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/readme", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"type": "file",
+			"encoding": "base64",
+			"size": 17,
+			"content": "IyBEZWNrYXJkCgpIZWxsbwo=",
+			"html_url": "https://github.com/o/r/blob/master/README.md"
+		}`)
+	})
+	mux.HandleFunc("/repos/o/empty/readme", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	body, htmlURL, size, err := c.GetReadme("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "# Deckard\n\nHello\n"; body != want {
+		t.Errorf("got body %q, want %q", body, want)
+	}
+	if want := "https://github.com/o/r/blob/master/README.md"; htmlURL != want {
+		t.Errorf("got URL %q, want %q", htmlURL, want)
+	}
+	if size != 17 {
+		t.Errorf("got size %d, want 17", size)
+	}
+
+	if _, _, _, err := c.GetReadme("o", "empty"); !errors.Is(err, ErrNoReadme) {
+		t.Errorf("got error %v, want %v", err, ErrNoReadme)
+	}
+}
+
+func TestCheckGithubRepo(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantFound bool
+		wantErr   bool
+	}{
+		{"found", http.StatusOK, true, false},
+		{"not found", http.StatusNotFound, false, false},
+		{"server error", http.StatusInternalServerError, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			calls := 0
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"name": "r"}`)
+			})
+
+			found, err := c.checkGithubRepo("o", "r")
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %t", err, tt.wantErr)
+			}
+			if found != tt.wantFound {
+				t.Errorf("got found %t, want %t", found, tt.wantFound)
+			}
+			if calls != 1 {
+				t.Errorf("got %d API calls, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestCheckRepoAndBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/master", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "master", "commit": {"sha": "abc123"}}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Branch not found"}`, http.StatusNotFound)
+	})
+
+	if err := c.checkRepoAndBranch("o", "r", "master"); err != nil {
+		t.Errorf("unexpected error for existing branch: %s", err)
+	}
+
+	err := c.checkRepoAndBranch("o", "r", "missing")
+	if err == nil {
+		t.Fatal("expected an error for a missing branch, got nil")
+	}
+	want := "Github branch not found: missing in repo r"
+	if err.Error() != want {
+		t.Errorf("got error %q, want %q", err.Error(), want)
+	}
+}
+
+func TestGetArchive(t *testing.T) {
+	tests := []struct {
+		format   string
+		wantPath string
+	}{
+		{"", "/repos/o/r/tarball/abc123"},
+		{ArchiveTar, "/repos/o/r/tarball/abc123"},
+		{ArchiveZip, "/repos/o/r/zipball/abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"name": "r"}`)
+			})
+			mux.HandleFunc("/repos/o/r/branches/master", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"name": "master", "commit": {"sha": "abc123"}}`)
+			})
+			mux.HandleFunc("/repos/o/r/commits/master", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "abc123")
+			})
+			var gotPath string
+			archive := func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				http.Redirect(w, r, "https://codeload.example.com/archive", http.StatusFound)
+			}
+			mux.HandleFunc("/repos/o/r/tarball/", archive)
+			mux.HandleFunc("/repos/o/r/zipball/", archive)
+
+			u, sha, err := c.GetArchive("o", "r", "master", tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("got request for %q, want %q", gotPath, tt.wantPath)
+			}
+			if u.String() != "https://codeload.example.com/archive" || sha != "abc123" {
+				t.Errorf("got %s at %s, want the redirect URL at abc123", u, sha)
+			}
+		})
+	}
+
+	c, _, teardown := setup()
+	defer teardown()
+	if _, _, err := c.GetArchive("o", "r", "master", "rar"); err == nil {
+		t.Error("expected an error for an unknown format, got nil")
+	}
+}
+
+func TestGetArchiveDefaultBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r", "default_branch": "main"}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "main", "commit": {"sha": "abc123"}}`)
+	})
+	mux.HandleFunc("/repos/o/r/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "abc123")
+	})
+	mux.HandleFunc("/repos/o/r/tarball/abc123", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://codeload.example.com/archive", http.StatusFound)
+	})
+
+	_, sha, err := c.GetArchive("o", "r", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if sha != "abc123" {
+		t.Errorf("got SHA %q, want abc123", sha)
+	}
+}
+
+func TestDownloadArchive(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	// a tarball holding README.md
+	var tarball bytes.Buffer
+	tw := tar.NewWriter(&tarball)
+	tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0644, Size: 5})
+	tw.Write([]byte("hello"))
+	tw.Close()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/master", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "master", "commit": {"sha": "abc123"}}`)
+	})
+	mux.HandleFunc("/repos/o/r/commits/master", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "abc123")
+	})
+	mux.HandleFunc("/repos/o/r/tarball/abc123", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://"+r.Host+"/codeload/o/r/master.tar", http.StatusFound)
+	})
+	mux.HandleFunc("/codeload/o/r/master.tar", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(tarball.Bytes())
+	})
+
+	body, sha, err := c.DownloadArchive("o", "r", "master")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer body.Close()
+	if sha != "abc123" {
+		t.Errorf("got SHA %q, want abc123", sha)
+	}
+	tr := tar.NewReader(body)
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatalf("could not read tarball: %s", err)
+	}
+	content, _ := ioutil.ReadAll(tr)
+	if hdr.Name != "README.md" || string(content) != "hello" {
+		t.Errorf("got %s containing %q, want README.md containing hello", hdr.Name, content)
+	}
+}
+
+func TestWithContextCanceled(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type": "file", "encoding": "base64", "content": "aGVsbG8="}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := c.WithContext(ctx).GetFile("o", "r", "README.md", "")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestNewEnterpriseClient(t *testing.T) {
+	c, err := NewEnterpriseClient("", "https://github.example.com/api/v3", "https://github.example.com/api/uploads/")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := c.client.BaseURL.String(), "https://github.example.com/api/v3/"; got != want {
+		t.Errorf("got BaseURL %q, want %q", got, want)
+	}
+	if got, want := c.client.UploadURL.String(), "https://github.example.com/api/uploads/"; got != want {
+		t.Errorf("got UploadURL %q, want %q", got, want)
+	}
+
+	for _, bad := range []string{"", "github.example.com", "://bad"} {
+		if _, err := NewEnterpriseClient("", bad, bad); err == nil {
+			t.Errorf("expected an error for URL %q, got nil", bad)
+		}
+	}
+}
+
+func TestCreateGithubIssueWithOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts IssueOptions
+		want github.IssueRequest
+	}{
+		{
+			name: "defaults",
+			want: github.IssueRequest{
+				Title: github.String("t"),
+				Body:  github.String(defaultIssueBody),
+			},
+		},
+		{
+			name: "body, labels and assignees",
+			opts: IssueOptions{Body: "b", Labels: []string{"bug", "p1"}, Assignees: []string{"deckard"}},
+			want: github.IssueRequest{
+				Title:     github.String("t"),
+				Body:      github.String("b"),
+				Labels:    &[]string{"bug", "p1"},
+				Assignees: &[]string{"deckard"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"name": "r"}`)
+			})
+			mux.HandleFunc("/repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+				var got github.IssueRequest
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got request %+v, want %+v", got, tt.want)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"number": 1, "html_url": "https://github.com/o/r/issues/1"}`)
+			})
+
+			out, err := c.CreateGithubIssueWithOptions("o", "r", "t", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want := "*Issue # 1 has been created successfully*\nhttps://github.com/o/r/issues/1"
+			if out != want {
+				t.Errorf("got %q, want %q", out, want)
+			}
+		})
+	}
+}
+
+func TestCreateIssue(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+	mux.HandleFunc("/repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number": 1, "title": "t", "html_url": "u1", "assignee": {"login": "deckard"}}`)
+	})
+
+	got, err := c.CreateIssue("o", "r", "t", IssueOptions{Assignees: []string{"deckard"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := IssueSummary{Number: 1, Title: "t", URL: "u1", Assignee: "deckard"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListMembers(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/members", pagesHandler(
+		`[{"login": "deckard"}, {"login": "rachael"}]`,
+		`[{"login": "gaff"}]`,
+	))
+
+	got, err := c.ListMembers("o")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"deckard", "rachael", "gaff"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGetGithubUsers(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/members", pagesHandler(
+		`[{"login": "deckard"}, {"login": "rachael"}]`,
+		`[{"login": "gaff"}]`,
+	))
+
+	out, err := c.GetGithubUsers("o")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "*Here's a list of all o Github usernames:*\n\"deckard\"\n\"rachael\"\n\"gaff\""
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestGetGithubUsersPerPage(t *testing.T) {
+	var members []string
+	for i := 0; i < 150; i++ {
+		members = append(members, fmt.Sprintf(`{"login": "user%d"}`, i))
+	}
+
+	tests := []struct {
+		perPage   int
+		wantCalls int
+	}{
+		{DefaultPerPage, 2},
+		{10, 15},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.perPage), func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			if c.PerPage != DefaultPerPage {
+				t.Fatalf("got default PerPage %d, want %d", c.PerPage, DefaultPerPage)
+			}
+			c.PerPage = tt.perPage
+
+			calls := 0
+			mux.HandleFunc("/orgs/o/members", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+				if perPage != tt.perPage {
+					t.Errorf("got per_page %d, want %d", perPage, tt.perPage)
+				}
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				if page < 1 {
+					page = 1
+				}
+				start, end := (page-1)*perPage, page*perPage
+				if end < len(members) {
+					w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d&per_page=%d>; rel="next"`, r.URL.Path, page+1, perPage))
+				} else {
+					end = len(members)
+				}
+				fmt.Fprintf(w, "[%s]", strings.Join(members[start:end], ","))
+			})
+
+			out, err := c.GetGithubUsers("o")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := strings.Count(out, "\n"); got != len(members) {
+				t.Errorf("got %d users, want %d", got, len(members))
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d API calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestGetGithubUsersError(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/members", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Server Error"}`, http.StatusInternalServerError)
+	})
+
+	out, err := c.GetGithubUsers("o")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("got error %v, want an APIError with status 500", err)
+	}
+	if out != "" {
+		t.Errorf("got output %q on error, want none", out)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/rate_limit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"resources": {
+			"core": {"limit": 5000, "remaining": 4999, "reset": 1500000000},
+			"search": {"limit": 30, "remaining": 12, "reset": 1500000060}
+		}}`)
+	})
+
+	limits, err := c.RateLimit()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &RateLimits{
+		Core:   Rate{Limit: 5000, Remaining: 4999, Reset: time.Unix(1500000000, 0)},
+		Search: Rate{Limit: 30, Remaining: 12, Reset: time.Unix(1500000060, 0)},
+	}
+	if !reflect.DeepEqual(limits, want) {
+		t.Errorf("got %+v, want %+v", limits, want)
+	}
+}
+
+func TestOctocat(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "from Github",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "octocat says "+r.URL.Query().Get("s"))
+			},
+			want: "octocat says hi",
+		},
+		{
+			name: "Github error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, `{"message": "Server Error"}`, http.StatusInternalServerError)
+			},
+			want: fmt.Sprintf(fallbackOctocat, "hi"),
+		},
+		{
+			name:    "empty response",
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			mux.HandleFunc("/octocat", tt.handler)
+
+			got, err := c.Octocat("hi")
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedOrgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		org     string
+		wantErr bool
+	}{
+		{name: "allowed", allowed: []string{"o", "other"}, org: "o"},
+		{name: "allowed ignoring case", allowed: []string{"O"}, org: "o"},
+		{name: "denied", allowed: []string{"other"}, org: "o", wantErr: true},
+		{name: "empty allowlist allows all", org: "o"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			c.AllowedOrgs = tt.allowed
+			calls := 0
+			mux.HandleFunc("/repos/o/r/issues/1", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				fmt.Fprint(w, `{"number": 1, "title": "Broken"}`)
+			})
+
+			_, err := c.GetIssue("o", "r", 1)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOrgNotAllowed) {
+					t.Errorf("got error %v, want ErrOrgNotAllowed", err)
+				}
+				if calls != 0 {
+					t.Errorf("got %d API calls, want none", calls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if calls != 1 {
+				t.Errorf("got %d API calls, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestAllowedOrgsCheckedBeforeDryRun(t *testing.T) {
+	c := NewClient("")
+	c.AllowedOrgs = []string{"other"}
+	c.DryRun = true
+	if _, err := c.CreateBranch("o", "r", "feature", "master"); !errors.Is(err, ErrOrgNotAllowed) {
+		t.Errorf("got error %v, want ErrOrgNotAllowed", err)
+	}
+	if err := c.DeleteBranch("o", "r", "feature"); !errors.Is(err, ErrOrgNotAllowed) {
+		t.Errorf("got error %v, want ErrOrgNotAllowed", err)
+	}
+}
+
+func TestAllowedOrgsForkDestination(t *testing.T) {
+	c := NewClient("token")
+	c.AllowedOrgs = []string{"o"}
+	if _, err := c.ForkRepo("o", "r", "elsewhere"); !errors.Is(err, ErrOrgNotAllowed) {
+		t.Errorf("got error %v, want ErrOrgNotAllowed", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/repos/o/r", stuckHandler)
+
+	for _, apiKey := range []string{"", "token"} {
+		c := NewClient(apiKey, WithTimeout(time.Millisecond))
+		u, _ := url.Parse(server.URL + "/")
+		c.client.BaseURL = u
+
+		_, err := c.GetRepoStats("o", "r")
+		var netErr interface{ Timeout() bool }
+		if !errors.As(err, &netErr) || !netErr.Timeout() {
+			t.Errorf("API key %q: got error %v, want a timeout", apiKey, err)
+		}
+	}
+}
+
+func TestDefaultCallTimeoutWithinHTTPTimeout(t *testing.T) {
+	c := NewClient("")
+	if c.CallTimeout > c.httpClient.Timeout {
+		t.Errorf("got CallTimeout %s longer than the HTTP timeout %s, so it would never apply", c.CallTimeout, c.httpClient.Timeout)
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	if got := NewClient("").httpClient.Timeout; got != DefaultTimeout {
+		t.Errorf("got timeout %s, want %s", got, DefaultTimeout)
+	}
+	if got := NewClient("token").httpClient.Timeout; got != DefaultTimeout {
+		t.Errorf("got timeout %s, want %s", got, DefaultTimeout)
+	}
+	if http.DefaultClient.Timeout != 0 {
+		t.Error("http.DefaultClient was changed")
+	}
+}
+
+// recordingTransport records the requests sent through it
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, req)
+	rt.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientWithHTTP(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+
+	for apiKey, wantAuth := range map[string]string{"": "", "token": "Bearer token"} {
+		rt := &recordingTransport{}
+		httpClient := &http.Client{Transport: rt, Timeout: 5 * time.Second}
+		c := NewClientWithHTTP(apiKey, httpClient)
+		u, _ := url.Parse(server.URL + "/")
+		c.client.BaseURL = u
+
+		if _, err := c.GetRepoStats("o", "r"); err != nil {
+			t.Fatalf("API key %q: unexpected error: %s", apiKey, err)
+		}
+		if len(rt.requests) != 1 || rt.requests[0].URL.Path != "/repos/o/r" {
+			t.Fatalf("API key %q: got requests %v, want one for /repos/o/r", apiKey, rt.requests)
+		}
+		if got := rt.requests[0].Header.Get("Authorization"); got != wantAuth {
+			t.Errorf("API key %q: got Authorization %q, want %q", apiKey, got, wantAuth)
+		}
+		if httpClient.Transport != rt {
+			t.Errorf("API key %q: the injected http.Client was changed", apiKey)
+		}
+		if c.httpClient.Timeout != 5*time.Second {
+			t.Errorf("API key %q: got timeout %s, want the injected client's", apiKey, c.httpClient.Timeout)
+		}
+	}
+}
+
+func TestCheckRepoAccess(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		wantExists     bool
+		wantAccessible bool
+		wantErr        bool
+	}{
+		{name: "accessible", status: http.StatusOK, wantExists: true, wantAccessible: true},
+		{name: "missing or private", status: http.StatusNotFound},
+		{name: "blocked", status: http.StatusUnavailableForLegalReasons, wantExists: true},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"name": "r", "message": "Something"}`)
+			})
+
+			exists, accessible, err := c.CheckRepoAccess("o", "r")
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %t", err, tt.wantErr)
+			}
+			if exists != tt.wantExists || accessible != tt.wantAccessible {
+				t.Errorf("got exists %t, accessible %t, want %t, %t", exists, accessible, tt.wantExists, tt.wantAccessible)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/github"
+)
+
+// Health is the state of the Client's Github integration
+type Health struct {
+	// Authenticated is whether Github accepted the Client's API key. It's
+	// false for a Client without one
+	Authenticated bool
+	// Login is the user the API key belongs to
+	Login string
+	// CoreRemaining and SearchRemaining are the API quota left
+	CoreRemaining   int
+	SearchRemaining int
+}
+
+// HealthCheck checks that Github accepts the Client's API key and reports
+// the quota left. A Client without an API key isn't an error, it just
+// reports Authenticated as false. If Github rejects the key, the Health is
+// returned along with the error
+func (c *Client) HealthCheck() (*Health, error) {
+	health := &Health{}
+	if c.authenticated {
+		var u *github.User
+		resp, err := c.do("Users.Get", func(ctx context.Context) (resp *github.Response, err error) {
+			u, resp, err = c.client.Users.Get(ctx, "")
+			return
+		})
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return health, fmt.Errorf("Github rejected the API key: %w", newAPIError(resp, err))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Could not fetch the authenticated Github user: %w", newAPIError(resp, err))
+		}
+		health.Authenticated = true
+		health.Login = u.GetLogin()
+	}
+	limits, err := c.RateLimit()
+	if err != nil {
+		return nil, err
+	}
+	health.CoreRemaining = limits.Core.Remaining
+	health.SearchRemaining = limits.Search.Remaining
+	return health, nil
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func rateLimitHandler(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, `{"resources": {
+		"core": {"limit": 5000, "remaining": 4999, "reset": 1500000000},
+		"search": {"limit": 30, "remaining": 12, "reset": 1500000060}
+	}}`)
+}
+
+func TestHealthCheck(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login": "deckard"}`)
+	})
+	mux.HandleFunc("/rate_limit", rateLimitHandler)
+
+	got, err := c.HealthCheck()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := Health{Authenticated: true, Login: "deckard", CoreRemaining: 4999, SearchRemaining: 12}
+	if *got != want {
+		t.Errorf("got %+v, want %+v", *got, want)
+	}
+}
+
+func TestHealthCheckUnauthenticated(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request for the authenticated user")
+	})
+	mux.HandleFunc("/rate_limit", rateLimitHandler)
+
+	got, err := c.HealthCheck()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := Health{CoreRemaining: 4999, SearchRemaining: 12}
+	if *got != want {
+		t.Errorf("got %+v, want %+v", *got, want)
+	}
+}
+
+func TestHealthCheckRejectedKey(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Bad credentials"}`, http.StatusUnauthorized)
+	})
+
+	got, err := c.HealthCheck()
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if got == nil || got.Authenticated {
+		t.Errorf("got %+v, want a Health that isn't Authenticated", got)
+	}
+}
@@ -0,0 +1,453 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// CommentOnIssue adds a comment to an existing issue or pull request and
+// returns a message with a link to the new comment
+func (c *Client) CommentOnIssue(org, repo string, number int, body string) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if msg, ok := c.dryRun("commented on %s/%s #%d", org, repo, number); ok {
+		return msg, nil
+	}
+	if _, err := c.Repo(org, repo).writable(); err != nil {
+		return "", err
+	}
+	var comment *github.IssueComment
+	resp, err := c.do("Issues.CreateComment", func(ctx context.Context) (resp *github.Response, err error) {
+		comment, resp, err = c.client.Issues.CreateComment(ctx, org, repo, number, &github.IssueComment{
+			Body: github.String(body),
+		})
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when commenting on issue: %w", newAPIError(resp, err))
+	}
+	return fmt.Sprintf("*Comment added to issue # %d*\n%s", number, comment.GetHTMLURL()), nil
+}
+
+// CloseIssue closes an issue and returns a confirmation with the issue's URL.
+// Closing an issue that's already closed is not an error
+func (c *Client) CloseIssue(org, repo string, number int) (string, error) {
+	return c.setIssueState(org, repo, number, "closed")
+}
+
+// ReopenIssue reopens a closed issue and returns a confirmation with the issue's URL.
+// Reopening an issue that's already open is not an error
+func (c *Client) ReopenIssue(org, repo string, number int) (string, error) {
+	return c.setIssueState(org, repo, number, "open")
+}
+
+// setIssueState sets the state of an issue to "open" or "closed"
+func (c *Client) setIssueState(org, repo string, number int, state string) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if msg, ok := c.dryRun("set %s/%s #%d to %s", org, repo, number, state); ok {
+		return msg, nil
+	}
+	var i *github.Issue
+	resp, err := c.do("Issues.Edit", func(ctx context.Context) (resp *github.Response, err error) {
+		i, resp, err = c.client.Issues.Edit(ctx, org, repo, number, &github.IssueRequest{
+			State: github.String(state),
+		})
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when updating issue: %w", newAPIError(resp, err))
+	}
+	return fmt.Sprintf("*Issue # %d is now %s*\n%s", number, i.GetState(), i.GetHTMLURL()), nil
+}
+
+// EditIssue changes the title and body of an issue and returns its URL. Only
+// non-empty fields are changed, so either can be left as it is
+func (c *Client) EditIssue(org, repo string, number int, title, body string) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if title == "" && body == "" {
+		return "", errors.New("A new title or body is required to edit an issue")
+	}
+	if msg, ok := c.dryRun("edited %s/%s #%d", org, repo, number); ok {
+		return msg, nil
+	}
+	edit := &github.IssueRequest{}
+	if title != "" {
+		edit.Title = github.String(title)
+	}
+	if body != "" {
+		edit.Body = github.String(body)
+	}
+	var i *github.Issue
+	resp, err := c.do("Issues.Edit", func(ctx context.Context) (resp *github.Response, err error) {
+		i, resp, err = c.client.Issues.Edit(ctx, org, repo, number, edit)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when editing issue: %w", newAPIError(resp, err))
+	}
+	return i.GetHTMLURL(), nil
+}
+
+// LockIssue locks the conversation on an issue or pull request so only
+// collaborators can comment. reason must be "off-topic", "too heated",
+// "resolved", "spam" or empty. Locking a locked issue is not an error
+func (c *Client) LockIssue(org, repo string, number int, reason string) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	switch reason {
+	case "", "off-topic", "too heated", "resolved", "spam":
+	default:
+		return fmt.Errorf("Invalid lock reason %q: must be off-topic, too heated, resolved or spam", reason)
+	}
+	if _, ok := c.dryRun("locked %s/%s #%d", org, repo, number); ok {
+		return nil
+	}
+	resp, err := c.do("Issues.Lock", func(ctx context.Context) (*github.Response, error) {
+		return c.client.Issues.Lock(ctx, org, repo, number, &github.LockIssueOptions{LockReason: reason})
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when locking issue: %w", newAPIError(resp, err))
+	}
+	return nil
+}
+
+// UnlockIssue unlocks the conversation on an issue or pull request.
+// Unlocking an issue that isn't locked is not an error
+func (c *Client) UnlockIssue(org, repo string, number int) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("unlocked %s/%s #%d", org, repo, number); ok {
+		return nil
+	}
+	resp, err := c.do("Issues.Unlock", func(ctx context.Context) (*github.Response, error) {
+		return c.client.Issues.Unlock(ctx, org, repo, number)
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when unlocking issue: %w", newAPIError(resp, err))
+	}
+	return nil
+}
+
+// IssueDetail describes an issue or pull request in full
+type IssueDetail struct {
+	Number    int
+	Title     string
+	Body      string
+	State     string
+	Labels    []string
+	Assignees []string
+	// Milestone is the milestone's title, or empty if it has none
+	Milestone string
+	Comments  int
+	URL       string
+	// PullRequest is set if this is a pull request rather than an issue
+	PullRequest bool
+}
+
+// GetIssue returns the details of an issue or pull request, or
+// ErrIssueNotFound if it doesn't exist
+func (c *Client) GetIssue(org, repo string, number int) (*IssueDetail, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	var i *github.Issue
+	resp, err := c.do("Issues.Get", func(ctx context.Context) (resp *github.Response, err error) {
+		i, resp, err = c.client.Issues.Get(ctx, org, repo, number)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch issue %s #%d: %w", repo, number, newAPIError(resp, err))
+	}
+	detail := &IssueDetail{
+		Number:      i.GetNumber(),
+		Title:       i.GetTitle(),
+		Body:        i.GetBody(),
+		State:       i.GetState(),
+		Milestone:   i.GetMilestone().GetTitle(),
+		Comments:    i.GetComments(),
+		URL:         i.GetHTMLURL(),
+		PullRequest: i.IsPullRequest(),
+	}
+	for _, l := range i.Labels {
+		detail.Labels = append(detail.Labels, l.GetName())
+	}
+	for _, u := range i.Assignees {
+		detail.Assignees = append(detail.Assignees, u.GetLogin())
+	}
+	return detail, nil
+}
+
+// MaxIssueComments is the most comments ListIssueComments returns
+const MaxIssueComments = 100
+
+// Comment is a comment on an issue or pull request
+type Comment struct {
+	Author    string
+	Body      string
+	CreatedAt time.Time
+	URL       string
+}
+
+// ListIssueComments returns the comments on an issue or pull request, oldest
+// first. Threads longer than MaxIssueComments are cut short, and the first
+// MaxIssueComments are returned along with an error wrapping ErrTruncated
+func (c *Client) ListIssueComments(org, repo string, number int) ([]Comment, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	truncated := fmt.Errorf("%w: showing the first %d comments on %s #%d", ErrTruncated, MaxIssueComments, repo, number)
+	// one more than the maximum shows whether there are too many
+	all, resp, err := paginateLimit(&opt.ListOptions, MaxIssueComments+1, func() ([]*github.IssueComment, *github.Response, error) {
+		var page []*github.IssueComment
+		resp, err := c.do("Issues.ListComments", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Issues.ListComments(ctx, org, repo, number, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch comments for %s #%d: %w", repo, number, newAPIError(resp, err))
+	}
+	var comments []Comment
+	for _, ic := range all {
+		if len(comments) == MaxIssueComments {
+			return comments, truncated
+		}
+		comments = append(comments, Comment{
+			Author:    ic.GetUser().GetLogin(),
+			Body:      ic.GetBody(),
+			CreatedAt: ic.GetCreatedAt(),
+			URL:       ic.GetHTMLURL(),
+		})
+	}
+	return comments, nil
+}
+
+// IssueSummary is a short description of an issue
+type IssueSummary struct {
+	Number   int
+	Title    string
+	URL      string
+	Assignee string
+}
+
+// ListIssues returns all issues in a repo with the given state, which must
+// be "open", "closed" or "all". Pull requests are not included
+func (c *Client) ListIssues(org, repo, state string) ([]IssueSummary, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	switch state {
+	case "open", "closed", "all":
+	default:
+		return nil, fmt.Errorf("Invalid issue state %q: must be open, closed or all", state)
+	}
+	opt := &github.IssueListByRepoOptions{
+		State:       state,
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	all, resp, err := paginate(&opt.ListOptions, func() ([]*github.Issue, *github.Response, error) {
+		var page []*github.Issue
+		resp, err := c.do("Issues.ListByRepo", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Issues.ListByRepo(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch issues for %s: %w", repo, newAPIError(resp, err))
+	}
+	var issues []IssueSummary
+	for _, i := range all {
+		// the issues endpoint returns pull requests too
+		if i.IsPullRequest() {
+			continue
+		}
+		issues = append(issues, IssueSummary{
+			Number:   i.GetNumber(),
+			Title:    i.GetTitle(),
+			URL:      i.GetHTMLURL(),
+			Assignee: i.GetAssignee().GetLogin(),
+		})
+	}
+	return issues, nil
+}
+
+// AssignIssue adds assignees to an existing issue and returns a confirmation
+// naming who was assigned. Assignees must be members of org; any that aren't
+// are skipped and reported in an *UnknownUsersError, returned alongside the
+// confirmation for the rest
+func (c *Client) AssignIssue(org, repo string, number int, assignees []string) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if msg, ok := c.dryRun("assigned %s to %s/%s #%d", strings.Join(assignees, ", "), org, repo, number); ok {
+		return msg, nil
+	}
+	valid, unknownErr, err := c.orgMembers(org, assignees)
+	if err != nil {
+		return "", err
+	}
+	if len(valid) == 0 {
+		return "", unknownErr
+	}
+
+	var i *github.Issue
+	resp, err := c.do("Issues.AddAssignees", func(ctx context.Context) (resp *github.Response, err error) {
+		i, resp, err = c.client.Issues.AddAssignees(ctx, org, repo, number, valid)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when assigning issue: %w", newAPIError(resp, err))
+	}
+	out := fmt.Sprintf("*Assigned %s to issue # %d*\n%s", strings.Join(valid, ", "), number, i.GetHTMLURL())
+	return out, unknownErr
+}
+
+// RemoveAssignees unassigns users from an issue or pull request and returns a
+// message listing who is still assigned. Github ignores users who weren't
+// assigned, so removing them does nothing
+func (c *Client) RemoveAssignees(org, repo string, number int, assignees []string) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if msg, ok := c.dryRun("unassigned %s from %s/%s #%d", strings.Join(assignees, ", "), org, repo, number); ok {
+		return msg, nil
+	}
+	var i *github.Issue
+	resp, err := c.do("Issues.RemoveAssignees", func(ctx context.Context) (resp *github.Response, err error) {
+		i, resp, err = c.client.Issues.RemoveAssignees(ctx, org, repo, number, assignees)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when unassigning issue: %w", newAPIError(resp, err))
+	}
+	var remaining []string
+	for _, u := range i.Assignees {
+		remaining = append(remaining, u.GetLogin())
+	}
+	if len(remaining) == 0 {
+		return fmt.Sprintf("*Issue # %d has no assignees*\n%s", number, i.GetHTMLURL()), nil
+	}
+	return fmt.Sprintf("*Issue # %d is assigned to %s*\n%s", number, strings.Join(remaining, ", "), i.GetHTMLURL()), nil
+}
+
+// AddLabels adds labels to an existing issue
+func (c *Client) AddLabels(org, repo string, number int, labels []string) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("added labels %s to %s/%s #%d", strings.Join(labels, ", "), org, repo, number); ok {
+		return nil
+	}
+	resp, err := c.do("Issues.AddLabelsToIssue", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Issues.AddLabelsToIssue(ctx, org, repo, number, labels)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when adding labels: %w", newAPIError(resp, err))
+	}
+	return nil
+}
+
+// labelNotFoundMessage is Github's error message for removing a label an
+// issue doesn't have
+const labelNotFoundMessage = "Label does not exist"
+
+// RemoveLabel removes a label from an existing issue. Removing a label the
+// issue doesn't have is not an error, but ErrIssueNotFound is returned if the
+// issue or repo doesn't exist
+func (c *Client) RemoveLabel(org, repo string, number int, label string) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("removed label %s from %s/%s #%d", label, org, repo, number); ok {
+		return nil
+	}
+	resp, err := c.do("Issues.RemoveLabelForIssue", func(ctx context.Context) (resp *github.Response, err error) {
+		resp, err = c.client.Issues.RemoveLabelForIssue(ctx, org, repo, number, label)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// Github returns a 404 if the label isn't on the issue, as well as
+		// for missing issues and repos
+		if errorMessage(err) == labelNotFoundMessage {
+			return nil
+		}
+		return fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when removing label: %w", newAPIError(resp, err))
+	}
+	return nil
+}
+
+// ListLabels returns the names of all labels defined in a repo
+func (c *Client) ListLabels(org, repo string) ([]string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.ListOptions{PerPage: c.PerPage}
+	all, resp, err := paginate(opt, func() ([]*github.Label, *github.Response, error) {
+		var page []*github.Label
+		resp, err := c.do("Issues.ListLabels", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Issues.ListLabels(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch labels for %s: %w", repo, newAPIError(resp, err))
+	}
+	var labels []string
+	for _, l := range all {
+		labels = append(labels, l.GetName())
+	}
+	return labels, nil
+}
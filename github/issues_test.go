@@ -0,0 +1,551 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCommentOnIssue(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"full_name": "o/r"}`)
+	})
+	mux.HandleFunc("/repos/o/r/issues/5/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("got method %s, want POST", r.Method)
+		}
+		var got github.IssueComment
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.GetBody() != "looks good" {
+			t.Errorf("got comment body %q, want %q", got.GetBody(), "looks good")
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "html_url": "https://github.com/o/r/issues/5#issuecomment-1"}`)
+	})
+
+	out, err := c.CommentOnIssue("o", "r", 5, "looks good")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "*Comment added to issue # 5*\nhttps://github.com/o/r/issues/5#issuecomment-1"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestCommentOnIssueNotFound(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"full_name": "o/r"}`)
+	})
+	mux.HandleFunc("/repos/o/r/issues/99/comments", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	_, err := c.CommentOnIssue("o", "r", 99, "hello?")
+	if !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrIssueNotFound)
+	}
+}
+
+func TestArchivedRepoRefusesWrites(t *testing.T) {
+	tests := []struct {
+		name string
+		repo string
+		call func(c *Client) error
+	}{
+		{"CreateGithubIssue archived", `{"archived": true}`, func(c *Client) error {
+			_, err := c.CreateGithubIssue("o", "r", "It's broken")
+			return err
+		}},
+		{"CommentOnIssue archived", `{"archived": true}`, func(c *Client) error {
+			_, err := c.CommentOnIssue("o", "r", 5, "hi")
+			return err
+		}},
+		{"CreateBranch archived", `{"archived": true}`, func(c *Client) error {
+			_, err := c.CreateBranch("o", "r", "feature", "master")
+			return err
+		}},
+		{"CommentOnIssue disabled", `{"disabled": true}`, func(c *Client) error {
+			_, err := c.CommentOnIssue("o", "r", 5, "hi")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.repo)
+			})
+			mux.HandleFunc("/repos/o/r/", func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request %s %s to an archived repo", r.Method, r.URL.Path)
+			})
+
+			if err := tt.call(c); !errors.Is(err, ErrRepoArchived) {
+				t.Errorf("got error %v, want %v", err, ErrRepoArchived)
+			}
+		})
+	}
+}
+
+func TestSetIssueState(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(c *Client) (string, error)
+		wantState string
+	}{
+		{"close", func(c *Client) (string, error) { return c.CloseIssue("o", "r", 5) }, "closed"},
+		{"close again", func(c *Client) (string, error) { return c.CloseIssue("o", "r", 5) }, "closed"},
+		{"reopen", func(c *Client) (string, error) { return c.ReopenIssue("o", "r", 5) }, "open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r/issues/5", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PATCH" {
+					t.Errorf("got method %s, want PATCH", r.Method)
+				}
+				var got github.IssueRequest
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got.GetState() != tt.wantState {
+					t.Errorf("got state %q, want %q", got.GetState(), tt.wantState)
+				}
+				fmt.Fprintf(w, `{"number": 5, "state": %q, "html_url": "https://github.com/o/r/issues/5"}`, tt.wantState)
+			})
+
+			out, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want := "*Issue # 5 is now " + tt.wantState + "*\nhttps://github.com/o/r/issues/5"
+			if out != want {
+				t.Errorf("got %q, want %q", out, want)
+			}
+		})
+	}
+}
+
+func TestEditIssue(t *testing.T) {
+	tests := []struct {
+		name        string
+		title, body string
+		want        map[string]interface{}
+	}{
+		{name: "title only", title: "New title", want: map[string]interface{}{"title": "New title"}},
+		{name: "body only", body: "New body", want: map[string]interface{}{"body": "New body"}},
+		{name: "both", title: "New title", body: "New body", want: map[string]interface{}{"title": "New title", "body": "New body"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r/issues/5", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PATCH" {
+					t.Errorf("got method %s, want PATCH", r.Method)
+				}
+				var got map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got request %v, want %v", got, tt.want)
+				}
+				fmt.Fprint(w, `{"number": 5, "html_url": "https://github.com/o/r/issues/5"}`)
+			})
+
+			out, err := c.EditIssue("o", "r", 5, tt.title, tt.body)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := "https://github.com/o/r/issues/5"; out != want {
+				t.Errorf("got %q, want %q", out, want)
+			}
+		})
+	}
+}
+
+func TestCloseIssueNotFound(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/99", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	if _, err := c.CloseIssue("o", "r", 99); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrIssueNotFound)
+	}
+}
+
+func TestGetIssue(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": 1,
+			"number": 5,
+			"state": "open",
+			"title": "Found a bug",
+			"body": "I'm having a problem with this.",
+			"user": {"login": "rachael"},
+			"labels": [{"name": "bug", "color": "f29513"}, {"name": "p1"}],
+			"assignee": {"login": "deckard"},
+			"assignees": [{"login": "deckard"}, {"login": "gaff"}],
+			"milestone": {"number": 1, "title": "v1.0", "state": "open"},
+			"locked": false,
+			"comments": 3,
+			"html_url": "https://github.com/o/r/issues/5",
+			"created_at": "2019-04-22T13:33:48Z"
+		}`)
+	})
+	mux.HandleFunc("/repos/o/r/issues/6", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"number": 6, "title": "Fix the bug", "pull_request": {"url": "https://api.github.com/repos/o/r/pulls/6"}}`)
+	})
+
+	got, err := c.GetIssue("o", "r", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &IssueDetail{
+		Number:    5,
+		Title:     "Found a bug",
+		Body:      "I'm having a problem with this.",
+		State:     "open",
+		Labels:    []string{"bug", "p1"},
+		Assignees: []string{"deckard", "gaff"},
+		Milestone: "v1.0",
+		Comments:  3,
+		URL:       "https://github.com/o/r/issues/5",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	pr, err := c.GetIssue("o", "r", 6)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !pr.PullRequest {
+		t.Error("expected #6 to be reported as a pull request")
+	}
+
+	if _, err := c.GetIssue("o", "r", 7); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrIssueNotFound)
+	}
+}
+
+func TestListIssues(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	pages := pagesHandler(`[
+			{"number": 1, "title": "first", "html_url": "u1", "assignee": {"login": "deckard"}},
+			{"number": 2, "title": "a pr", "html_url": "u2", "pull_request": {"url": "p2"}}
+		]`,
+		`[{"number": 3, "title": "third", "html_url": "u3"}]`,
+	)
+	mux.HandleFunc("/repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != "all" {
+			t.Errorf("got state %q, want %q", got, "all")
+		}
+		pages(w, r)
+	})
+
+	issues, err := c.ListIssues("o", "r", "all")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []IssueSummary{
+		{Number: 1, Title: "first", URL: "u1", Assignee: "deckard"},
+		{Number: 3, Title: "third", URL: "u3"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("got %+v, want %+v", issues, want)
+	}
+
+	if _, err := c.ListIssues("o", "r", "bogus"); err == nil {
+		t.Error("expected an error for an invalid state, got nil")
+	}
+}
+
+func TestAssignIssue(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "deckard"}, {"login": "rachael"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/issues/5/assignees", func(w http.ResponseWriter, r *http.Request) {
+		var got struct {
+			Assignees []string `json:"assignees"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got.Assignees, []string{"deckard"}) {
+			t.Errorf("got assignees %q, want only the known user", got.Assignees)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number": 5, "html_url": "https://github.com/o/r/issues/5"}`)
+	})
+
+	out, err := c.AssignIssue("o", "r", 5, []string{"deckard", "roy"})
+	want := "*Assigned deckard to issue # 5*\nhttps://github.com/o/r/issues/5"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+	var unknownErr *UnknownUsersError
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("got error %v, want *UnknownUsersError", err)
+	}
+	if got, want := err.Error(), "Unknown o Github usernames: roy"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+
+	if _, err := c.AssignIssue("o", "r", 5, []string{"roy"}); !errors.As(err, &unknownErr) {
+		t.Errorf("got error %v, want *UnknownUsersError when no assignees are known", err)
+	}
+}
+
+func TestRemoveAssignees(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	// Github keeps everyone who wasn't asked to be removed
+	assigned := []string{"deckard", "rachael"}
+	mux.HandleFunc("/repos/o/r/issues/5/assignees", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("got method %s, want DELETE", r.Method)
+		}
+		var got struct {
+			Assignees []string `json:"assignees"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		var remaining []string
+		for _, a := range assigned {
+			removed := false
+			for _, g := range got.Assignees {
+				removed = removed || g == a
+			}
+			if !removed {
+				remaining = append(remaining, fmt.Sprintf(`{"login": %q}`, a))
+			}
+		}
+		fmt.Fprintf(w, `{"number": 5, "html_url": "https://github.com/o/r/issues/5", "assignees": [%s]}`, strings.Join(remaining, ","))
+	})
+
+	tests := []struct {
+		remove []string
+		want   string
+	}{
+		{[]string{"rachael"}, "*Issue # 5 is assigned to deckard*\nhttps://github.com/o/r/issues/5"},
+		{[]string{"roy"}, "*Issue # 5 is assigned to deckard, rachael*\nhttps://github.com/o/r/issues/5"},
+		{[]string{"deckard", "rachael"}, "*Issue # 5 has no assignees*\nhttps://github.com/o/r/issues/5"},
+	}
+	for _, tt := range tests {
+		out, err := c.RemoveAssignees("o", "r", 5, tt.remove)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if out != tt.want {
+			t.Errorf("removing %v: got %q, want %q", tt.remove, out, tt.want)
+		}
+	}
+}
+
+func TestAddLabels(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/5/labels", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("got method %s, want POST", r.Method)
+		}
+		var got []string
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"bug", "p1"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got labels %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `[{"name": "bug"}, {"name": "p1"}]`)
+	})
+
+	if err := c.AddLabels("o", "r", 5, []string{"bug", "p1"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestRemoveLabel(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/5/labels/bug", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("got method %s, want DELETE", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/issues/5/labels/absent", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Label does not exist"}`, http.StatusNotFound)
+	})
+
+	if err := c.RemoveLabel("o", "r", 5, "bug"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := c.RemoveLabel("o", "r", 5, "absent"); err != nil {
+		t.Errorf("removing an absent label should be a no-op, got %s", err)
+	}
+}
+
+func TestRemoveLabelIssueNotFound(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/99/labels/bug", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	if err := c.RemoveLabel("o", "r", 99, "bug"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrIssueNotFound)
+	}
+}
+
+func TestListLabels(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/labels", pagesHandler(
+		`[{"name": "bug"}, {"name": "p1"}]`,
+		`[{"name": "wontfix"}]`,
+	))
+
+	labels, err := c.ListLabels("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"bug", "p1", "wontfix"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("got %q, want %q", labels, want)
+	}
+}
+
+func TestLockIssue(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	var gotReason string
+	mux.HandleFunc("/repos/o/r/issues/3/lock", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("got method %s, want PUT", r.Method)
+		}
+		var body struct {
+			LockReason string `json:"lock_reason"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		gotReason = body.LockReason
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.LockIssue("o", "r", 3, "too heated"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotReason != "too heated" {
+		t.Errorf("got lock reason %q, want %q", gotReason, "too heated")
+	}
+	if err := c.LockIssue("o", "r", 3, "boring"); err == nil {
+		t.Error("expected an invalid lock reason error")
+	}
+}
+
+func TestUnlockIssue(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/3/lock", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("got method %s, want DELETE", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/o/r/issues/4/lock", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	if err := c.UnlockIssue("o", "r", 3); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.UnlockIssue("o", "r", 4); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrIssueNotFound)
+	}
+}
+
+func TestListIssueComments(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/3/comments", pagesHandler(
+		`[{"user": {"login": "alice"}, "body": "First", "created_at": "2019-01-02T15:04:05Z", "html_url": "https://github.com/o/r/issues/3#issuecomment-1"}]`,
+		`[{"user": {"login": "bob"}, "body": "Second", "created_at": "2019-01-03T15:04:05Z", "html_url": "https://github.com/o/r/issues/3#issuecomment-2"}]`,
+	))
+
+	got, err := c.ListIssueComments("o", "r", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []Comment{
+		{Author: "alice", Body: "First", CreatedAt: time.Date(2019, 1, 2, 15, 4, 5, 0, time.UTC), URL: "https://github.com/o/r/issues/3#issuecomment-1"},
+		{Author: "bob", Body: "Second", CreatedAt: time.Date(2019, 1, 3, 15, 4, 5, 0, time.UTC), URL: "https://github.com/o/r/issues/3#issuecomment-2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListIssueCommentsTruncated(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	page := func(n int) string {
+		comments := make([]string, n)
+		for i := range comments {
+			comments[i] = `{"body": "+1"}`
+		}
+		return "[" + strings.Join(comments, ",") + "]"
+	}
+	mux.HandleFunc("/repos/o/r/issues/3/comments", pagesHandler(page(60), page(60)))
+
+	got, err := c.ListIssueComments("o", "r", 3)
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("got error %v, want %v", err, ErrTruncated)
+	}
+	if len(got) != MaxIssueComments {
+		t.Errorf("got %d comments, want %d", len(got), MaxIssueComments)
+	}
+}
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/github"
+)
+
+// LicenseInfo is the license Github detected for a repo
+type LicenseInfo struct {
+	// SPDXID is the license's SPDX identifier, e.g. "MIT" or "Apache-2.0"
+	SPDXID string
+	Name   string
+	// HTMLURL links to the license file in the repo
+	HTMLURL string
+}
+
+// GetLicense returns the license of a repo. Returns ErrNoLicense if the repo
+// has no license file, or has one Github can't identify
+func (c *Client) GetLicense(org, repo string) (*LicenseInfo, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	var l *github.RepositoryLicense
+	resp, err := c.do("Repositories.License", func(ctx context.Context) (resp *github.Response, err error) {
+		l, resp, err = c.client.Repositories.License(ctx, org, repo)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNoLicense, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch license for %s: %w", repo, newAPIError(resp, err))
+	}
+	// Github has no SPDX ID for license files it doesn't recognize
+	spdxID := l.GetLicense().GetSPDXID()
+	if spdxID == "" || spdxID == "NOASSERTION" {
+		return nil, fmt.Errorf("%w: Github doesn't recognize the license in %s", ErrNoLicense, repo)
+	}
+	return &LicenseInfo{
+		SPDXID:  spdxID,
+		Name:    l.GetLicense().GetName(),
+		HTMLURL: l.GetHTMLURL(),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetLicense(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/license", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"name": "LICENSE",
+			"html_url": "https://github.com/o/r/blob/master/LICENSE",
+			"license": {"key": "mit", "name": "MIT License", "spdx_id": "MIT"}
+		}`)
+	})
+
+	got, err := c.GetLicense("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := LicenseInfo{SPDXID: "MIT", Name: "MIT License", HTMLURL: "https://github.com/o/r/blob/master/LICENSE"}
+	if *got != want {
+		t.Errorf("got %+v, want %+v", *got, want)
+	}
+}
+
+func TestGetLicenseUnlicensed(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"no license file", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}},
+		{"unrecognized license", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"name": "LICENSE", "license": {"key": "other", "name": "Other", "spdx_id": "NOASSERTION"}}`)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			mux.HandleFunc("/repos/o/r/license", tt.handler)
+
+			if _, err := c.GetLicense("o", "r"); !errors.Is(err, ErrNoLicense) {
+				t.Errorf("got error %v, want %v", err, ErrNoLicense)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package github
+
+import "github.com/handwritingio/deckard-bot/log"
+
+// Logger is what the package logs through. Set Client.Logger to send logs
+// somewhere other than the log package, or to change how verbose they are.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// defaultLogger logs through the log package
+type defaultLogger struct{}
+
+func (defaultLogger) Debugf(format string, args ...interface{}) { log.Debugf(format, args...) }
+func (defaultLogger) Infof(format string, args ...interface{})  { log.Infof(format, args...) }
+func (defaultLogger) Warnf(format string, args ...interface{})  { log.Warnf(format, args...) }
+func (defaultLogger) Errorf(format string, args ...interface{}) { log.Errorf(format, args...) }
+
+// logger returns the Client's Logger, or the default one if it isn't set
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return defaultLogger{}
+	}
+	return c.Logger
+}
@@ -0,0 +1,83 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger keeps every line logged to it, prefixed with its level
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, level+": "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.record("debug", format, args...)
+}
+func (l *recordingLogger) Infof(format string, args ...interface{}) {
+	l.record("info", format, args...)
+}
+func (l *recordingLogger) Warnf(format string, args ...interface{}) {
+	l.record("warn", format, args...)
+}
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.record("error", format, args...)
+}
+
+func TestLoggerQuietOnRepoAndUserLookups(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	logger := &recordingLogger{}
+	c.Logger = logger
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+	mux.HandleFunc("/orgs/o/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "alice"}, {"login": "bob"}]`)
+	})
+
+	if _, err := c.checkGithubRepo("o", "r"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetGithubUsers("o"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(logger.lines) != 0 {
+		t.Errorf("expected nothing to be logged, got %q", logger.lines)
+	}
+}
+
+func TestLoggerReceivesWarnings(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	logger := &recordingLogger{}
+	c.Logger = logger
+	mux.HandleFunc("/octocat", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Server Error"}`, http.StatusInternalServerError)
+	})
+
+	if _, err := c.Octocat("hi"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(logger.lines) != 1 || !strings.HasPrefix(logger.lines[0], "warn: Could not fetch octocat") {
+		t.Errorf("got %q, want one octocat warning", logger.lines)
+	}
+}
+
+func TestNilLoggerUsesDefault(t *testing.T) {
+	c := &Client{}
+	if _, ok := c.logger().(defaultLogger); !ok {
+		t.Errorf("got %T, want defaultLogger", c.logger())
+	}
+}
@@ -0,0 +1,15 @@
+package github
+
+import "time"
+
+// Metrics records the Github API calls a Client makes, e.g. to export them
+// to Prometheus. method is the go-github method called, such as
+// "Repositories.Get". Implementations must be safe for concurrent use
+type Metrics interface {
+	// IncRequest is called before every request, including retries
+	IncRequest(method string)
+	// IncError is called for every request that returns an error
+	IncError(method string)
+	// ObserveLatency is called with how long every request took
+	ObserveLatency(method string, d time.Duration)
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeMetrics counts the calls made to it
+type fakeMetrics struct {
+	mu        sync.Mutex
+	requests  map[string]int
+	errors    map[string]int
+	latencies map[string]int
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{
+		requests:  make(map[string]int),
+		errors:    make(map[string]int),
+		latencies: make(map[string]int),
+	}
+}
+
+func (m *fakeMetrics) IncRequest(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[method]++
+}
+
+func (m *fakeMetrics) IncError(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[method]++
+}
+
+func (m *fakeMetrics) ObserveLatency(method string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latencies[method]++
+}
+
+func TestMetrics(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	m := newFakeMetrics()
+	c.Metrics = m
+	c.CacheTTL = 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+	mux.HandleFunc("/users/broken", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Server Error"}`, http.StatusInternalServerError)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.checkGithubRepo("o", "r"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if _, err := c.GetUser("broken"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	wantRequests := map[string]int{"Repositories.Get": 2, "Users.Get": 1}
+	if !reflect.DeepEqual(m.requests, wantRequests) {
+		t.Errorf("got requests %v, want %v", m.requests, wantRequests)
+	}
+	if !reflect.DeepEqual(m.latencies, wantRequests) {
+		t.Errorf("got latencies %v, want %v", m.latencies, wantRequests)
+	}
+	wantErrors := map[string]int{"Users.Get": 1}
+	if !reflect.DeepEqual(m.errors, wantErrors) {
+		t.Errorf("got errors %v, want %v", m.errors, wantErrors)
+	}
+}
+
+func TestMetricsCountsRetries(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	m := newFakeMetrics()
+	c.Metrics = m
+	calls := 0
+	mux.HandleFunc("/repos/o/r", abuseHandler(1, `{"name": "r"}`, &calls))
+
+	if _, err := c.checkGithubRepo("o", "r"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := m.requests["Repositories.Get"]; got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+	if got := m.errors["Repositories.Get"]; got != 1 {
+		t.Errorf("got %d errors, want 1", got)
+	}
+}
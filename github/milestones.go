@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// Milestone is a milestone of a repo
+type Milestone struct {
+	Number       int
+	Title        string
+	OpenIssues   int
+	ClosedIssues int
+	// DueOn is nil if the milestone has no due date
+	DueOn *time.Time
+}
+
+// ListMilestones returns all milestones in a repo with the given state,
+// which must be "open", "closed" or "all"
+func (c *Client) ListMilestones(org, repo, state string) ([]Milestone, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	switch state {
+	case "open", "closed", "all":
+	default:
+		return nil, fmt.Errorf("Invalid milestone state %q: must be open, closed or all", state)
+	}
+	opt := &github.MilestoneListOptions{
+		State:       state,
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	all, resp, err := paginate(&opt.ListOptions, func() ([]*github.Milestone, *github.Response, error) {
+		var page []*github.Milestone
+		resp, err := c.do("Issues.ListMilestones", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Issues.ListMilestones(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch milestones for %s: %w", repo, newAPIError(resp, err))
+	}
+	var milestones []Milestone
+	for _, m := range all {
+		milestones = append(milestones, Milestone{
+			Number:       m.GetNumber(),
+			Title:        m.GetTitle(),
+			OpenIssues:   m.GetOpenIssues(),
+			ClosedIssues: m.GetClosedIssues(),
+			DueOn:        m.DueOn,
+		})
+	}
+	return milestones, nil
+}
+
+// CreateMilestone creates a milestone in a repo and returns its URL. due
+// may be nil for a milestone without a due date
+func (c *Client) CreateMilestone(org, repo, title, description string, due *time.Time) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if msg, ok := c.dryRun("created milestone %q in %s/%s", title, org, repo); ok {
+		return msg, nil
+	}
+	milestone := &github.Milestone{
+		Title: github.String(title),
+		DueOn: due,
+	}
+	if description != "" {
+		milestone.Description = github.String(description)
+	}
+	var m *github.Milestone
+	resp, err := c.do("Issues.CreateMilestone", func(ctx context.Context) (resp *github.Response, err error) {
+		m, resp, err = c.client.Issues.CreateMilestone(ctx, org, repo, milestone)
+		return
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when creating milestone: %w", newAPIError(resp, err))
+	}
+	return m.GetHTMLURL(), nil
+}
+
+// SetMilestone puts an issue or pull request in a milestone, or takes it out
+// of its milestone if milestoneNumber is 0. Returns ErrMilestoneNotFound if
+// the milestone doesn't exist
+func (c *Client) SetMilestone(org, repo string, issueNumber, milestoneNumber int) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("set the milestone of %s/%s #%d to %d", org, repo, issueNumber, milestoneNumber); ok {
+		return nil
+	}
+	// a nil milestone is sent as null, which clears it
+	var milestone *int
+	if milestoneNumber != 0 {
+		resp, err := c.do("Issues.GetMilestone", func(ctx context.Context) (resp *github.Response, err error) {
+			_, resp, err = c.client.Issues.GetMilestone(ctx, org, repo, milestoneNumber)
+			return
+		})
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %s #%d", ErrMilestoneNotFound, repo, milestoneNumber)
+		}
+		if err != nil {
+			return fmt.Errorf("Could not fetch milestone %d for %s: %w", milestoneNumber, repo, newAPIError(resp, err))
+		}
+		milestone = &milestoneNumber
+	}
+
+	// github.IssueRequest omits a nil milestone, so it can't clear one
+	u := fmt.Sprintf("repos/%s/%s/issues/%d", org, repo, issueNumber)
+	resp, err := c.do("Issues.Edit", func(ctx context.Context) (*github.Response, error) {
+		req, err := c.client.NewRequest("PATCH", u, struct {
+			Milestone *int `json:"milestone"`
+		}{milestone})
+		if err != nil {
+			return nil, err
+		}
+		return c.client.Do(ctx, req, nil)
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, issueNumber)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when setting milestone: %w", newAPIError(resp, err))
+	}
+	return nil
+}
+
+// MilestoneProgress is an open milestone and how far along it is
+type MilestoneProgress struct {
+	Milestone
+	// PercentComplete is the share of the milestone's issues that are
+	// closed, rounded down. It's 0 for a milestone without issues
+	PercentComplete int
+}
+
+// MilestoneProgress returns the progress of every open milestone in a repo
+func (c *Client) MilestoneProgress(org, repo string) ([]MilestoneProgress, error) {
+	milestones, err := c.ListMilestones(org, repo, "open")
+	if err != nil {
+		return nil, err
+	}
+	progress := make([]MilestoneProgress, 0, len(milestones))
+	for _, m := range milestones {
+		p := MilestoneProgress{Milestone: m}
+		if total := m.OpenIssues + m.ClosedIssues; total > 0 {
+			p.PercentComplete = m.ClosedIssues * 100 / total
+		}
+		progress = append(progress, p)
+	}
+	return progress, nil
+}
@@ -0,0 +1,156 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListMilestones(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/milestones", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("state") {
+		case "open":
+			fmt.Fprint(w, `[{"number": 2, "title": "v1.1", "open_issues": 4, "closed_issues": 1, "due_on": "2019-03-01T08:00:00Z"}]`)
+		case "closed":
+			fmt.Fprint(w, `[{"number": 1, "title": "v1.0", "open_issues": 0, "closed_issues": 9}]`)
+		default:
+			t.Errorf("got state %q", r.URL.Query().Get("state"))
+		}
+	})
+
+	due := time.Date(2019, 3, 1, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		state string
+		want  []Milestone
+	}{
+		{"open", []Milestone{{Number: 2, Title: "v1.1", OpenIssues: 4, ClosedIssues: 1, DueOn: &due}}},
+		{"closed", []Milestone{{Number: 1, Title: "v1.0", ClosedIssues: 9}}},
+	}
+	for _, tt := range tests {
+		got, err := c.ListMilestones("o", "r", tt.state)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got %+v for %s, want %+v", got, tt.state, tt.want)
+		}
+	}
+
+	if _, err := c.ListMilestones("o", "r", "done"); err == nil {
+		t.Error("expected an error for an invalid state, got nil")
+	}
+}
+
+func TestCreateMilestone(t *testing.T) {
+	due := time.Date(2019, 3, 1, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		due     *time.Time
+		wantDue string
+	}{
+		{"with due date", &due, "2019-03-01T08:00:00Z"},
+		{"without due date", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r/milestones", func(w http.ResponseWriter, r *http.Request) {
+				var got map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got["title"] != "v1.2" || got["description"] != "Next release" {
+					t.Errorf("got request %v", got)
+				}
+				if got["due_on"] != tt.wantDue {
+					t.Errorf("got due_on %q, want %q", got["due_on"], tt.wantDue)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"number": 3, "html_url": "https://github.com/o/r/milestone/3"}`)
+			})
+
+			got, err := c.CreateMilestone("o", "r", "v1.2", "Next release", tt.due)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := "https://github.com/o/r/milestone/3"; got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSetMilestone(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/milestones/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"number": 3, "title": "v1.2"}`)
+	})
+	mux.HandleFunc("/repos/o/r/milestones/9", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+	var bodies []string
+	mux.HandleFunc("/repos/o/r/issues/5", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("got method %s, want PATCH", r.Method)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(b)))
+		fmt.Fprint(w, `{"number": 5}`)
+	})
+
+	if err := c.SetMilestone("o", "r", 5, 3); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.SetMilestone("o", "r", 5, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{`{"milestone":3}`, `{"milestone":null}`}; !reflect.DeepEqual(bodies, want) {
+		t.Errorf("got requests %v, want %v", bodies, want)
+	}
+
+	if err := c.SetMilestone("o", "r", 5, 9); !errors.Is(err, ErrMilestoneNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrMilestoneNotFound)
+	}
+}
+
+func TestMilestoneProgress(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/milestones", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != "open" {
+			t.Errorf("got state %q, want open", got)
+		}
+		fmt.Fprint(w, `[
+			{"number": 1, "title": "v1", "open_issues": 1, "closed_issues": 3},
+			{"number": 2, "title": "v2", "open_issues": 2, "closed_issues": 1},
+			{"number": 3, "title": "v3", "open_issues": 0, "closed_issues": 0}
+		]`)
+	})
+
+	progress, err := c.MilestoneProgress("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := make(map[string]int)
+	for _, p := range progress {
+		got[p.Title] = p.PercentComplete
+	}
+	if want := map[string]int{"v1": 75, "v2": 33, "v3": 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got progress %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// Notification is a Github notification for the Client's user
+type Notification struct {
+	// ID is the notification's thread ID
+	ID    string
+	Title string
+	// Type is the kind of subject, e.g. "Issue", "PullRequest" or "Release"
+	Type string
+	// Repo is the full name of the repo, e.g. "handwritingio/deckard-bot"
+	Repo string
+	// URL is the API URL of the subject
+	URL string
+}
+
+// ListNotifications returns the notifications of the Client's user. Only
+// unread notifications are included unless all is set. Returns
+// ErrAuthRequired without an API key
+func (c *Client) ListNotifications(all bool) ([]Notification, error) {
+	if !c.authenticated {
+		return nil, fmt.Errorf("Can't list notifications: %w", ErrAuthRequired)
+	}
+	opt := &github.NotificationListOptions{
+		All:         all,
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	notes, resp, err := paginate(&opt.ListOptions, func() ([]*github.Notification, *github.Response, error) {
+		var page []*github.Notification
+		resp, err := c.do("Activity.ListNotifications", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Activity.ListNotifications(ctx, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch notifications: %w", newAPIError(resp, err))
+	}
+	notifications := make([]Notification, 0, len(notes))
+	for _, n := range notes {
+		notifications = append(notifications, Notification{
+			ID:    n.GetID(),
+			Title: n.GetSubject().GetTitle(),
+			Type:  n.GetSubject().GetType(),
+			Repo:  n.GetRepository().GetFullName(),
+			URL:   n.GetSubject().GetURL(),
+		})
+	}
+	return notifications, nil
+}
+
+// MarkNotificationRead marks the notification thread with threadID as read.
+// Returns ErrAuthRequired without an API key
+func (c *Client) MarkNotificationRead(threadID string) error {
+	if !c.authenticated {
+		return fmt.Errorf("Can't mark notification %s read: %w", threadID, ErrAuthRequired)
+	}
+	if _, ok := c.dryRun("marked notification %s read", threadID); ok {
+		return nil
+	}
+	resp, err := c.do("Activity.MarkThreadRead", func(ctx context.Context) (*github.Response, error) {
+		return c.client.Activity.MarkThreadRead(ctx, threadID)
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Notification %s not found", threadID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when marking notification %s read: %w", threadID, newAPIError(resp, err))
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks the notifications of the Client's user up
+// to before as read. A zero time marks them all read.
+// Returns ErrAuthRequired without an API key
+func (c *Client) MarkAllNotificationsRead(before time.Time) error {
+	if !c.authenticated {
+		return fmt.Errorf("Can't mark notifications read: %w", ErrAuthRequired)
+	}
+	if _, ok := c.dryRun("marked notifications read"); ok {
+		return nil
+	}
+	resp, err := c.do("Activity.MarkNotificationsRead", func(ctx context.Context) (*github.Response, error) {
+		if !before.IsZero() {
+			return c.client.Activity.MarkNotificationsRead(ctx, before)
+		}
+		// go-github always sends last_read_at, which would be year 1 here.
+		// Without it Github marks everything up to now read
+		req, err := c.client.NewRequest("PUT", "notifications", nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.client.Do(ctx, req, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("Error occurred when marking notifications read: %w", newAPIError(resp, err))
+	}
+	return nil
+}
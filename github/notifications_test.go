@@ -0,0 +1,115 @@
+package github
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListNotifications(t *testing.T) {
+	for _, all := range []bool{false, true} {
+		c, mux, teardown := setup()
+		c.authenticated = true
+
+		var gotAll []string
+		pages := pagesHandler(
+			`[{"id": "1", "repository": {"full_name": "o/r"}, "subject": {"title": "Fix it", "type": "Issue", "url": "u1"}}]`,
+			`[{"id": "2", "repository": {"full_name": "o/s"}, "subject": {"title": "Ship it", "type": "PullRequest", "url": "u2"}}]`,
+		)
+		mux.HandleFunc("/notifications", func(w http.ResponseWriter, r *http.Request) {
+			gotAll = append(gotAll, r.URL.Query().Get("all"))
+			pages(w, r)
+		})
+
+		got, err := c.ListNotifications(all)
+		teardown()
+		if err != nil {
+			t.Fatalf("all=%t: unexpected error: %s", all, err)
+		}
+		want := []Notification{
+			{ID: "1", Title: "Fix it", Type: "Issue", Repo: "o/r", URL: "u1"},
+			{ID: "2", Title: "Ship it", Type: "PullRequest", Repo: "o/s", URL: "u2"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("all=%t: got %+v, want %+v", all, got, want)
+		}
+		wantAll := []string{"", ""}
+		if all {
+			wantAll = []string{"true", "true"}
+		}
+		if !reflect.DeepEqual(gotAll, wantAll) {
+			t.Errorf("all=%t: got all params %q, want %q", all, gotAll, wantAll)
+		}
+	}
+}
+
+func TestListNotificationsUnauthenticated(t *testing.T) {
+	c, _, teardown := setup()
+	defer teardown()
+
+	if _, err := c.ListNotifications(false); !errors.Is(err, ErrAuthRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAuthRequired)
+	}
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	marked := false
+	mux.HandleFunc("/notifications/threads/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("got method %s, want PATCH", r.Method)
+		}
+		marked = true
+		w.WriteHeader(http.StatusResetContent)
+	})
+
+	if err := c.MarkNotificationRead("1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !marked {
+		t.Error("notification wasn't marked read")
+	}
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	before := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		before   time.Time
+		wantBody string
+	}{
+		{name: "before a time", before: before, wantBody: `{"last_read_at":"2026-10-01T12:00:00Z"}` + "\n"},
+		{name: "zero time marks everything", wantBody: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+			c.authenticated = true
+
+			var gotBody string
+			mux.HandleFunc("/notifications", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PUT" {
+					t.Errorf("got method %s, want PUT", r.Method)
+				}
+				b, _ := ioutil.ReadAll(r.Body)
+				gotBody = string(b)
+				w.WriteHeader(http.StatusResetContent)
+			})
+
+			if err := c.MarkAllNotificationsRead(tt.before); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotBody != tt.wantBody {
+				t.Errorf("got body %q, want %q", gotBody, tt.wantBody)
+			}
+		})
+	}
+}
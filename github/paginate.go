@@ -0,0 +1,31 @@
+package github
+
+import "github.com/google/go-github/github"
+
+// paginate calls fetch once per page and returns the items from every page.
+// fetch must request the page set in opt; paginate moves opt on to the next
+// page until Github reports there are no more. On error, the response and
+// error of the failed page are returned
+func paginate[T any](opt *github.ListOptions, fetch func() ([]T, *github.Response, error)) ([]T, *github.Response, error) {
+	return paginateLimit(opt, 0, fetch)
+}
+
+// paginateLimit is paginate, but stops once it has limit items and returns
+// only the first limit. A limit of zero or less means no limit
+func paginateLimit[T any](opt *github.ListOptions, limit int, fetch func() ([]T, *github.Response, error)) ([]T, *github.Response, error) {
+	var all []T
+	for {
+		page, resp, err := fetch()
+		if err != nil {
+			return nil, resp, err
+		}
+		all = append(all, page...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], resp, nil
+		}
+		if resp.NextPage == 0 {
+			return all, resp, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestPaginateFollowsPages(t *testing.T) {
+	pages := map[int][]string{
+		0: {"a", "b"},
+		2: {"c"},
+		3: {"d", "e"},
+	}
+	next := map[int]int{0: 2, 2: 3}
+	opt := &github.ListOptions{}
+	var fetched []int
+	got, _, err := paginate(opt, func() ([]string, *github.Response, error) {
+		fetched = append(fetched, opt.Page)
+		return pages[opt.Page], &github.Response{NextPage: next[opt.Page]}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(fetched, []int{0, 2, 3}) {
+		t.Errorf("fetched pages %v, want [0 2 3]", fetched)
+	}
+}
+
+func TestPaginateStopsOnError(t *testing.T) {
+	opt := &github.ListOptions{}
+	boom := errors.New("boom")
+	calls := 0
+	got, _, err := paginate(opt, func() ([]int, *github.Response, error) {
+		calls++
+		if opt.Page == 2 {
+			return nil, nil, boom
+		}
+		return []int{1}, &github.Response{NextPage: 2}, nil
+	})
+	if err != boom {
+		t.Errorf("got error %v, want %v", err, boom)
+	}
+	if got != nil {
+		t.Errorf("got %v, want no results", got)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d pages, want 2", calls)
+	}
+}
+
+func TestPaginateLimit(t *testing.T) {
+	opt := &github.ListOptions{}
+	calls := 0
+	got, _, err := paginateLimit(opt, 3, func() ([]int, *github.Response, error) {
+		calls++
+		return []int{calls*10 + 1, calls*10 + 2}, &github.Response{NextPage: calls + 1}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []int{11, 12, 21}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d pages, want 2", calls)
+	}
+}
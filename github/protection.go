@@ -0,0 +1,126 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/github"
+)
+
+// Protection is the protection of a branch
+type Protection struct {
+	// RequiredChecks are the status checks that must pass before merging
+	RequiredChecks []string
+	// StrictChecks requires branches to be up to date before merging
+	StrictChecks bool
+	// RequiredReviews is how many approving reviews are needed to merge.
+	// Zero means reviews aren't required
+	RequiredReviews         int
+	DismissStaleReviews     bool
+	RequireCodeOwnerReviews bool
+	// EnforceAdmins applies the protection to admins too
+	EnforceAdmins bool
+}
+
+// ProtectionRequest is the protection ProtectBranch gives a branch
+type ProtectionRequest Protection
+
+// GetBranchProtection returns the protection of a branch. Returns
+// ErrNotProtected if it has none, and ErrBranchNotFound if it doesn't exist
+func (c *Client) GetBranchProtection(org, repo, branch string) (*Protection, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	var p *github.Protection
+	resp, err := c.do("Repositories.GetBranchProtection", func(ctx context.Context) (resp *github.Response, err error) {
+		p, resp, err = c.client.Repositories.GetBranchProtection(ctx, org, repo, branch)
+		return
+	})
+	if err := protectionError(resp, err, repo, branch); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch protection of %s in %s: %w", branch, repo, newAPIError(resp, err))
+	}
+	protection := &Protection{}
+	if ea := p.GetEnforceAdmins(); ea != nil {
+		protection.EnforceAdmins = ea.Enabled
+	}
+	if checks := p.GetRequiredStatusChecks(); checks != nil {
+		protection.RequiredChecks = checks.Contexts
+		protection.StrictChecks = checks.Strict
+	}
+	if reviews := p.GetRequiredPullRequestReviews(); reviews != nil {
+		protection.RequiredReviews = reviews.RequiredApprovingReviewCount
+		protection.DismissStaleReviews = reviews.DismissStaleReviews
+		protection.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+	}
+	return protection, nil
+}
+
+// ProtectBranch replaces the protection of a branch with req. Protection
+// Github supports that req doesn't cover, like push restrictions, is
+// removed. Returns ErrAdminRequired if the Client isn't an admin of the repo
+func (c *Client) ProtectBranch(org, repo, branch string, req ProtectionRequest) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if req.RequiredReviews < 0 || req.RequiredReviews > 6 {
+		return fmt.Errorf("Invalid number of required reviews %d: must be between 0 and 6", req.RequiredReviews)
+	}
+	if _, ok := c.dryRun("protected %s in %s/%s", branch, org, repo); ok {
+		return nil
+	}
+	preq := &github.ProtectionRequest{EnforceAdmins: req.EnforceAdmins}
+	if len(req.RequiredChecks) > 0 || req.StrictChecks {
+		contexts := req.RequiredChecks
+		if contexts == nil {
+			// Github needs a list, even an empty one
+			contexts = []string{}
+		}
+		preq.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict:   req.StrictChecks,
+			Contexts: contexts,
+		}
+	}
+	if req.RequiredReviews > 0 {
+		preq.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: req.RequiredReviews,
+			DismissStaleReviews:          req.DismissStaleReviews,
+			RequireCodeOwnerReviews:      req.RequireCodeOwnerReviews,
+		}
+	}
+	resp, err := c.do("Repositories.UpdateBranchProtection", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Repositories.UpdateBranchProtection(ctx, org, repo, branch, preq)
+		return
+	})
+	if err := protectionError(resp, err, repo, branch); err != nil {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when protecting %s in %s: %w", branch, repo, newAPIError(resp, err))
+	}
+	return nil
+}
+
+// protectionError explains the errors Github gives for branch protection,
+// or returns nil for other errors
+func protectionError(resp *github.Response, err error, repo, branch string) error {
+	if resp == nil {
+		return nil
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		// missing branches and unprotected ones are both 404s
+		if errorMessage(err) == "Branch not protected" {
+			return fmt.Errorf("%w: %s in repo %s", ErrNotProtected, branch, repo)
+		}
+		return fmt.Errorf("%w: %s in repo %s", ErrBranchNotFound, branch, repo)
+	case http.StatusForbidden:
+		if _, ok := retryWait(resp, err); !ok {
+			return fmt.Errorf("%w: no access to the protection of %s in %s: %s", ErrAdminRequired, branch, repo, errorMessage(err))
+		}
+	}
+	return nil
+}
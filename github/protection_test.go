@@ -0,0 +1,109 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestGetBranchProtection(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/branches/master/protection", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"required_status_checks": {"strict": true, "contexts": ["ci/test"]},
+			"required_pull_request_reviews": {"dismiss_stale_reviews": true, "required_approving_review_count": 2},
+			"enforce_admins": {"enabled": true}
+		}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/develop/protection", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"required_status_checks": {"strict": false, "contexts": ["ci/test"]}}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/feature/protection", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Branch not protected"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/o/r/branches/gone/protection", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Branch not found"}`, http.StatusNotFound)
+	})
+
+	got, err := c.GetBranchProtection("o", "r", "master")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &Protection{
+		RequiredChecks:      []string{"ci/test"},
+		StrictChecks:        true,
+		RequiredReviews:     2,
+		DismissStaleReviews: true,
+		EnforceAdmins:       true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got, err = c.GetBranchProtection("o", "r", "develop")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := (&Protection{RequiredChecks: []string{"ci/test"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := c.GetBranchProtection("o", "r", "feature"); !errors.Is(err, ErrNotProtected) {
+		t.Errorf("got error %v, want %v", err, ErrNotProtected)
+	}
+	if _, err := c.GetBranchProtection("o", "r", "gone"); !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrBranchNotFound)
+	}
+}
+
+func TestProtectBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/branches/master/protection", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("got method %s, want PUT", r.Method)
+		}
+		var got github.ProtectionRequest
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		want := github.ProtectionRequest{
+			RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"ci/test"}},
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+				RequiredApprovingReviewCount: 1,
+				RequireCodeOwnerReviews:      true,
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got request %+v, want %+v", got, want)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/release/protection", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Must have admin rights to Repository."}`, http.StatusForbidden)
+	})
+
+	err := c.ProtectBranch("o", "r", "master", ProtectionRequest{
+		RequiredChecks:          []string{"ci/test"},
+		RequiredReviews:         1,
+		RequireCodeOwnerReviews: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := c.ProtectBranch("o", "r", "release", ProtectionRequest{}); !errors.Is(err, ErrAdminRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAdminRequired)
+	}
+	if err := c.ProtectBranch("o", "r", "master", ProtectionRequest{RequiredReviews: 7}); err == nil {
+		t.Error("expected an error for too many required reviews, got nil")
+	}
+}
@@ -0,0 +1,333 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// PullRequestSummary is a short description of a pull request
+type PullRequestSummary struct {
+	Number         int
+	Title          string
+	URL            string
+	User           string
+	MergeableState string
+}
+
+// ListPullRequests returns all pull requests in a repo with the given state,
+// which must be "open", "closed" or "all". An empty state means "open"
+func (c *Client) ListPullRequests(org, repo, state string) ([]PullRequestSummary, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	switch state {
+	case "":
+		state = "open"
+	case "open", "closed", "all":
+	default:
+		return nil, fmt.Errorf("Invalid pull request state %q: must be open, closed or all", state)
+	}
+	opt := &github.PullRequestListOptions{
+		State:       state,
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	all, resp, err := paginate(&opt.ListOptions, func() ([]*github.PullRequest, *github.Response, error) {
+		var page []*github.PullRequest
+		resp, err := c.do("PullRequests.List", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.PullRequests.List(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch pull requests for %s: %w", repo, newAPIError(resp, err))
+	}
+	var pulls []PullRequestSummary
+	for _, pr := range all {
+		pulls = append(pulls, PullRequestSummary{
+			Number:         pr.GetNumber(),
+			Title:          pr.GetTitle(),
+			URL:            pr.GetHTMLURL(),
+			User:           pr.GetUser().GetLogin(),
+			MergeableState: pr.GetMergeableState(),
+		})
+	}
+	return pulls, nil
+}
+
+// PullRequestRequest holds the fields used to open a pull request
+type PullRequestRequest struct {
+	Title string
+	// Head is the branch with the changes, Base is the branch they'll be merged into
+	Head string
+	Base string
+	Body string
+	// Draft opens the pull request as a draft, which can't be merged until
+	// it's marked ready for review
+	Draft bool
+}
+
+// CreatePullRequest opens a pull request and returns its URL, followed by
+// " (draft)" if it was opened as a draft. Head and Base must be different
+// branches that both exist in the repo
+func (c *Client) CreatePullRequest(org, repo string, opts PullRequestRequest) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if opts.Head == opts.Base {
+		return "", fmt.Errorf("Can't open a pull request from %s into itself", opts.Head)
+	}
+	if msg, ok := c.dryRun("opened a pull request from %s into %s in %s/%s", opts.Head, opts.Base, org, repo); ok {
+		return msg, nil
+	}
+	if err := c.checkRepoAndBranch(org, repo, opts.Base); err != nil {
+		return "", err
+	}
+	// a head of "user:branch" lives in a fork, so we can't check it here
+	if !strings.Contains(opts.Head, ":") {
+		if err := c.checkBranch(org, repo, opts.Head); err != nil {
+			return "", err
+		}
+	}
+
+	pull := &github.NewPullRequest{
+		Title: github.String(opts.Title),
+		Head:  github.String(opts.Head),
+		Base:  github.String(opts.Base),
+	}
+	if opts.Body != "" {
+		pull.Body = github.String(opts.Body)
+	}
+	var pr draftPullRequest
+	resp, err := c.do("PullRequests.Create", func(ctx context.Context) (resp *github.Response, err error) {
+		if !opts.Draft {
+			pr.PullRequest, resp, err = c.client.PullRequests.Create(ctx, org, repo, pull)
+			return
+		}
+		// go-github doesn't know about drafts, which are still a preview
+		req, err := c.client.NewRequest("POST", fmt.Sprintf("repos/%s/%s/pulls", org, repo), newDraftPullRequest{NewPullRequest: pull, Draft: true})
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", mediaTypeDraftPreview)
+		return c.client.Do(ctx, req, &pr)
+	})
+	if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity && noCommitsBetween(err) {
+		return "", fmt.Errorf("There are no commits on %s that aren't already on %s", opts.Head, opts.Base)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when creating pull request: %w", newAPIError(resp, err))
+	}
+	if pr.Draft {
+		return pr.GetHTMLURL() + " (draft)", nil
+	}
+	return pr.GetHTMLURL(), nil
+}
+
+// mediaTypeDraftPreview is needed to open draft pull requests
+const mediaTypeDraftPreview = "application/vnd.github.shadow-cat-preview+json"
+
+// newDraftPullRequest is a github.NewPullRequest with the draft flag
+type newDraftPullRequest struct {
+	*github.NewPullRequest
+	Draft bool `json:"draft"`
+}
+
+// draftPullRequest is a github.PullRequest with the draft flag
+type draftPullRequest struct {
+	*github.PullRequest
+	Draft bool `json:"draft"`
+}
+
+// noCommitsBetween reports whether err is Github refusing to open a pull
+// request because head has nothing to merge into base
+func noCommitsBetween(err error) bool {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	for _, e := range errResp.Errors {
+		if strings.HasPrefix(e.Message, "No commits between") {
+			return true
+		}
+	}
+	return false
+}
+
+// MergePullRequest merges a pull request using method, which must be "merge",
+// "squash" or "rebase", and returns the SHA of the merge commit.
+// ErrNotMergeable is returned if Github won't allow the merge, e.g. because
+// of conflicts or failing required checks
+func (c *Client) MergePullRequest(org, repo string, number int, method string) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	switch method {
+	case "merge", "squash", "rebase":
+	default:
+		return "", fmt.Errorf("Invalid merge method %q: must be merge, squash or rebase", method)
+	}
+	if msg, ok := c.dryRun("merged pull request %s/%s #%d with %s", org, repo, number, method); ok {
+		return msg, nil
+	}
+	var result *github.PullRequestMergeResult
+	resp, err := c.do("PullRequests.Merge", func(ctx context.Context) (resp *github.Response, err error) {
+		result, resp, err = c.client.PullRequests.Merge(ctx, org, repo, number, "", &github.PullRequestOptions{
+			MergeMethod: method,
+		})
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusMethodNotAllowed {
+		return "", fmt.Errorf("%w: %s #%d: %s", ErrNotMergeable, repo, number, errorMessage(err))
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when merging pull request: %w", newAPIError(resp, err))
+	}
+	return result.GetSHA(), nil
+}
+
+// RequestReviewers asks reviewers and teamReviewers, given as team slugs, to
+// review a pull request and returns a confirmation naming who was asked.
+// Reviewers must be members of org; any that aren't are skipped and reported
+// in an *UnknownUsersError, returned alongside the confirmation for the rest
+func (c *Client) RequestReviewers(org, repo string, number int, reviewers, teamReviewers []string) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	requested := append(append([]string{}, reviewers...), teamReviewers...)
+	if len(requested) == 0 {
+		return "", errors.New("At least one reviewer is required")
+	}
+	if msg, ok := c.dryRun("requested reviews from %s on %s/%s #%d", strings.Join(requested, ", "), org, repo, number); ok {
+		return msg, nil
+	}
+	var valid []string
+	var unknownErr error
+	if len(reviewers) > 0 {
+		var err error
+		valid, unknownErr, err = c.orgMembers(org, reviewers)
+		if err != nil {
+			return "", err
+		}
+	}
+	if len(valid) == 0 && len(teamReviewers) == 0 {
+		return "", unknownErr
+	}
+
+	var pr *github.PullRequest
+	resp, err := c.do("PullRequests.RequestReviewers", func(ctx context.Context) (resp *github.Response, err error) {
+		pr, resp, err = c.client.PullRequests.RequestReviewers(ctx, org, repo, number, github.ReviewersRequest{
+			Reviewers:     valid,
+			TeamReviewers: teamReviewers,
+		})
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when requesting reviewers: %w", newAPIError(resp, err))
+	}
+	asked := append(valid, teamReviewers...)
+	out := fmt.Sprintf("*Requested reviews from %s on pull request # %d*\n%s", strings.Join(asked, ", "), number, pr.GetHTMLURL())
+	return out, unknownErr
+}
+
+// MaxPullRequestFiles is the most files ListPullRequestFiles returns
+const MaxPullRequestFiles = 100
+
+// FileChange is a file changed by a pull request
+type FileChange struct {
+	Filename string
+	// Status is "added", "removed", "modified" or "renamed"
+	Status    string
+	Additions int
+	Deletions int
+	// Patch is the file's diff, empty for binary or very large files
+	Patch string
+}
+
+// ListPullRequestFiles returns the files a pull request changes. Pull
+// requests changing more than MaxPullRequestFiles files are cut short, and
+// the first MaxPullRequestFiles are returned along with an error wrapping
+// ErrTruncated
+func (c *Client) ListPullRequestFiles(org, repo string, number int) ([]FileChange, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	truncated := fmt.Errorf("%w: showing the first %d files of %s #%d", ErrTruncated, MaxPullRequestFiles, repo, number)
+	opt := &github.ListOptions{PerPage: c.PerPage}
+	// one more than the maximum shows whether there are too many
+	all, resp, err := paginateLimit(opt, MaxPullRequestFiles+1, func() ([]*github.CommitFile, *github.Response, error) {
+		var page []*github.CommitFile
+		resp, err := c.do("PullRequests.ListFiles", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.PullRequests.ListFiles(ctx, org, repo, number, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch files of %s #%d: %w", repo, number, newAPIError(resp, err))
+	}
+	var files []FileChange
+	for _, f := range all {
+		if len(files) == MaxPullRequestFiles {
+			return files, truncated
+		}
+		files = append(files, FileChange{
+			Filename:  f.GetFilename(),
+			Status:    f.GetStatus(),
+			Additions: f.GetAdditions(),
+			Deletions: f.GetDeletions(),
+			Patch:     f.GetPatch(),
+		})
+	}
+	return files, nil
+}
+
+// DefaultMaxDiffBytes is the MaxDiffBytes of a Client from NewClient
+const DefaultMaxDiffBytes = 10000
+
+// GetPullRequestDiff returns the unified diff of a pull request. Diffs longer
+// than the Client's MaxDiffBytes are cut at the last whole line that fits,
+// and returned along with an error wrapping ErrTruncated
+func (c *Client) GetPullRequestDiff(org, repo string, number int) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	u := fmt.Sprintf("repos/%s/%s/pulls/%d", org, repo, number)
+	req, err := c.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3.diff")
+	var buf bytes.Buffer
+	resp, err := c.do("PullRequests.GetRaw", func(ctx context.Context) (*github.Response, error) {
+		buf.Reset()
+		return c.client.Do(ctx, req, &buf)
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, number)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Could not fetch diff of %s #%d: %w", repo, number, newAPIError(resp, err))
+	}
+	diff := buf.String()
+	if c.MaxDiffBytes <= 0 || len(diff) <= c.MaxDiffBytes {
+		return diff, nil
+	}
+	cut := diff[:c.MaxDiffBytes]
+	if i := strings.LastIndexByte(cut, '\n'); i >= 0 {
+		cut = cut[:i+1]
+	}
+	return cut, fmt.Errorf("%w: the diff of %s #%d is %d bytes, showing the first %d", ErrTruncated, repo, number, len(diff), len(cut))
+}
@@ -0,0 +1,387 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestListPullRequests(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	pages := pagesHandler(
+		`[{"number": 1, "title": "one", "html_url": "u1", "user": {"login": "deckard"}, "mergeable_state": "clean"}]`,
+		`[{"number": 2, "title": "two", "html_url": "u2", "user": {"login": "rachael"}}]`,
+	)
+	var states []string
+	mux.HandleFunc("/repos/o/r/pulls", func(w http.ResponseWriter, r *http.Request) {
+		states = append(states, r.URL.Query().Get("state"))
+		pages(w, r)
+	})
+
+	pulls, err := c.ListPullRequests("o", "r", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []PullRequestSummary{
+		{Number: 1, Title: "one", URL: "u1", User: "deckard", MergeableState: "clean"},
+		{Number: 2, Title: "two", URL: "u2", User: "rachael"},
+	}
+	if !reflect.DeepEqual(pulls, want) {
+		t.Errorf("got %+v, want %+v", pulls, want)
+	}
+	if want := []string{"open", "open"}; !reflect.DeepEqual(states, want) {
+		t.Errorf("got states %q, want %q", states, want)
+	}
+
+	states = nil
+	if _, err := c.ListPullRequests("o", "r", "closed"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"closed", "closed"}; !reflect.DeepEqual(states, want) {
+		t.Errorf("got states %q, want %q", states, want)
+	}
+
+	if _, err := c.ListPullRequests("o", "r", "merged"); err == nil {
+		t.Error("expected an error for an invalid state, got nil")
+	}
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+	for _, b := range []string{"master", "feature", "merged"} {
+		mux.HandleFunc("/repos/o/r/branches/"+b, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"name": "b"}`)
+		})
+	}
+	mux.HandleFunc("/repos/o/r/pulls", func(w http.ResponseWriter, r *http.Request) {
+		var got github.NewPullRequest
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.GetHead() == "merged" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "Validation Failed", "errors": [{"resource": "PullRequest", "code": "custom", "message": "No commits between master and merged"}]}`)
+			return
+		}
+		want := github.NewPullRequest{
+			Title: github.String("Add feature"),
+			Head:  github.String("feature"),
+			Base:  github.String("master"),
+			Body:  github.String("please review"),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got pull request %+v, want %+v", got, want)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number": 7, "html_url": "https://github.com/o/r/pull/7"}`)
+	})
+
+	url, err := c.CreatePullRequest("o", "r", PullRequestRequest{
+		Title: "Add feature",
+		Head:  "feature",
+		Base:  "master",
+		Body:  "please review",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "https://github.com/o/r/pull/7"; url != want {
+		t.Errorf("got %q, want %q", url, want)
+	}
+
+	_, err = c.CreatePullRequest("o", "r", PullRequestRequest{Head: "merged", Base: "master"})
+	if want := "There are no commits on merged that aren't already on master"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
+
+func TestCreateDraftPullRequest(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+	for _, b := range []string{"master", "feature"} {
+		mux.HandleFunc("/repos/o/r/branches/"+b, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"name": "b"}`)
+		})
+	}
+	mux.HandleFunc("/repos/o/r/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != mediaTypeDraftPreview {
+			t.Errorf("got Accept %q, want %q", got, mediaTypeDraftPreview)
+		}
+		var got map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got["draft"] != true || got["head"] != "feature" {
+			t.Errorf("got pull request %v, want a draft from feature", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number": 7, "html_url": "https://github.com/o/r/pull/7", "draft": true}`)
+	})
+
+	url, err := c.CreatePullRequest("o", "r", PullRequestRequest{
+		Title: "Add feature",
+		Head:  "feature",
+		Base:  "master",
+		Draft: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "https://github.com/o/r/pull/7 (draft)"; url != want {
+		t.Errorf("got %q, want %q", url, want)
+	}
+}
+
+func TestCreatePullRequestMissingBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		head, base string
+	}{
+		{name: "head missing", head: "gone", base: "master"},
+		{name: "base missing", head: "feature", base: "gone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"name": "r"}`)
+			})
+			for _, b := range []string{"master", "feature"} {
+				mux.HandleFunc("/repos/o/r/branches/"+b, func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `{"name": "b"}`)
+				})
+			}
+			mux.HandleFunc("/repos/o/r/pulls", func(w http.ResponseWriter, r *http.Request) {
+				t.Error("pull request created with a missing branch")
+			})
+
+			_, err := c.CreatePullRequest("o", "r", PullRequestRequest{Head: tt.head, Base: tt.base})
+			if !errors.Is(err, ErrBranchNotFound) {
+				t.Fatalf("got error %v, want %v", err, ErrBranchNotFound)
+			}
+			if !strings.Contains(err.Error(), "gone") {
+				t.Errorf("got error %q, want it to name the missing branch", err)
+			}
+		})
+	}
+}
+
+func TestCreatePullRequestSameBranch(t *testing.T) {
+	c, _, teardown := setup()
+	defer teardown()
+
+	// no handlers are registered, so any API call would fail with a 404
+	_, err := c.CreatePullRequest("o", "r", PullRequestRequest{Head: "master", Base: "master"})
+	if want := "Can't open a pull request from master into itself"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
+
+func TestMergePullRequest(t *testing.T) {
+	for _, method := range []string{"merge", "squash", "rebase"} {
+		t.Run(method, func(t *testing.T) {
+			c, mux, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/repos/o/r/pulls/7/merge", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PUT" {
+					t.Errorf("got method %s, want PUT", r.Method)
+				}
+				var got struct {
+					MergeMethod string `json:"merge_method"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got.MergeMethod != method {
+					t.Errorf("got merge method %q, want %q", got.MergeMethod, method)
+				}
+				fmt.Fprint(w, `{"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e", "merged": true}`)
+			})
+
+			sha, err := c.MergePullRequest("o", "r", 7, method)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := "6dcb09b5b57875f334f61aebed695e2e4193db5e"; sha != want {
+				t.Errorf("got sha %q, want %q", sha, want)
+			}
+		})
+	}
+}
+
+func TestMergePullRequestNotMergeable(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/pulls/7/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprint(w, `{"message": "Pull Request is not mergeable"}`)
+	})
+
+	_, err := c.MergePullRequest("o", "r", 7, "merge")
+	if !errors.Is(err, ErrNotMergeable) {
+		t.Errorf("got error %v, want %v", err, ErrNotMergeable)
+	}
+	if _, err := c.MergePullRequest("o", "r", 7, "yolo"); err == nil {
+		t.Error("expected an error for an invalid merge method, got nil")
+	}
+}
+
+func TestRequestReviewers(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "deckard"}, {"login": "rachael"}]`)
+	})
+	var got github.ReviewersRequest
+	mux.HandleFunc("/repos/o/r/pulls/7/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		got = github.ReviewersRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number": 7, "html_url": "https://github.com/o/r/pull/7"}`)
+	})
+
+	out, err := c.RequestReviewers("o", "r", 7, []string{"deckard", "Rachael"}, []string{"core"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := github.ReviewersRequest{Reviewers: []string{"deckard", "Rachael"}, TeamReviewers: []string{"core"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got request %+v, want %+v", got, want)
+	}
+	if want := "*Requested reviews from deckard, Rachael, core on pull request # 7*\nhttps://github.com/o/r/pull/7"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestRequestReviewersUnknown(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/orgs/o/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "deckard"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/pulls/7/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		var got github.ReviewersRequest
+		json.NewDecoder(r.Body).Decode(&got)
+		if !reflect.DeepEqual(got.Reviewers, []string{"deckard"}) {
+			t.Errorf("got reviewers %q, want only the known user", got.Reviewers)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number": 7, "html_url": "https://github.com/o/r/pull/7"}`)
+	})
+
+	out, err := c.RequestReviewers("o", "r", 7, []string{"deckard", "roy"}, nil)
+	if want := "*Requested reviews from deckard on pull request # 7*\nhttps://github.com/o/r/pull/7"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+	var unknownErr *UnknownUsersError
+	if !errors.As(err, &unknownErr) || !reflect.DeepEqual(unknownErr.Users, []string{"roy"}) {
+		t.Fatalf("got error %v, want *UnknownUsersError naming roy", err)
+	}
+
+	if _, err := c.RequestReviewers("o", "r", 7, []string{"roy"}, nil); !errors.As(err, &unknownErr) {
+		t.Errorf("got error %v, want *UnknownUsersError when no reviewers are known", err)
+	}
+}
+
+func TestGetPullRequestDiff(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	const diff = "diff --git a/main.go b/main.go\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-package foo\n" +
+		"+package main\n"
+	mux.HandleFunc("/repos/o/r/pulls/7", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/vnd.github.v3.diff" {
+			t.Errorf("got Accept %q, want the diff media type", got)
+		}
+		fmt.Fprint(w, diff)
+	})
+
+	got, err := c.GetPullRequestDiff("o", "r", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != diff {
+		t.Errorf("got %q, want %q", got, diff)
+	}
+
+	// the limit falls in the middle of the fourth line
+	c.MaxDiffBytes = 65
+	got, err = c.GetPullRequestDiff("o", "r", 7)
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("got error %v, want %v", err, ErrTruncated)
+	}
+	if want := "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if len(got) > c.MaxDiffBytes {
+		t.Errorf("got %d bytes, want at most %d", len(got), c.MaxDiffBytes)
+	}
+}
+
+func TestListPullRequestFiles(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/pulls/7/files", pagesHandler(
+		`[{"filename": "main.go", "status": "modified", "additions": 2, "deletions": 1, "patch": "@@ -1 +1,2 @@"}]`,
+		`[{"filename": "logo.png", "status": "added"}]`,
+	))
+
+	got, err := c.ListPullRequestFiles("o", "r", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []FileChange{
+		{Filename: "main.go", Status: "modified", Additions: 2, Deletions: 1, Patch: "@@ -1 +1,2 @@"},
+		{Filename: "logo.png", Status: "added"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListPullRequestFilesTruncated(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	page := `[` + strings.TrimSuffix(strings.Repeat(`{"filename": "f.go"},`, 60), ",") + `]`
+	mux.HandleFunc("/repos/o/r/pulls/7/files", pagesHandler(page, page))
+
+	got, err := c.ListPullRequestFiles("o", "r", 7)
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("got error %v, want %v", err, ErrTruncated)
+	}
+	if len(got) != MaxPullRequestFiles {
+		t.Errorf("got %d files, want %d", len(got), MaxPullRequestFiles)
+	}
+}
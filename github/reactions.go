@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// Reactions lists the reaction contents Github accepts
+var Reactions = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// checkReaction returns an error if content isn't one of Reactions
+func checkReaction(content string) error {
+	for _, r := range Reactions {
+		if content == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid reaction %q: must be one of %s", content, strings.Join(Reactions, ", "))
+}
+
+// AddReaction reacts to an issue or pull request with content, which must
+// be one of Reactions. Reacting the same way twice is not an error
+func (c *Client) AddReaction(org, repo string, issueNumber int, content string) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if err := checkReaction(content); err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("reacted %s to %s/%s #%d", content, org, repo, issueNumber); ok {
+		return nil
+	}
+	resp, err := c.do("Reactions.CreateIssueReaction", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Reactions.CreateIssueReaction(ctx, org, repo, issueNumber, content)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s #%d", ErrIssueNotFound, repo, issueNumber)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when adding reaction: %w", newAPIError(resp, err))
+	}
+	return nil
+}
+
+// AddCommentReaction reacts to a comment on an issue or pull request with
+// content, which must be one of Reactions
+func (c *Client) AddCommentReaction(org, repo string, commentID int64, content string) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	if err := checkReaction(content); err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("reacted %s to comment %d in %s/%s", content, commentID, org, repo); ok {
+		return nil
+	}
+	resp, err := c.do("Reactions.CreateIssueCommentReaction", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Reactions.CreateIssueCommentReaction(ctx, org, repo, commentID, content)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Comment %d not found in repo %s", commentID, repo)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when adding reaction: %w", newAPIError(resp, err))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAddReaction(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r/issues/5/reactions", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var got struct{ Content string }
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Content != "rocket" {
+			t.Errorf("got content %q, want rocket", got.Content)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "content": "rocket"}`)
+	})
+
+	if err := c.AddReaction("o", "r", 5, "rocket"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.AddReaction("o", "r", 5, "thumbsup"); err == nil {
+		t.Error("expected an invalid reaction error")
+	}
+	if calls != 1 {
+		t.Errorf("got %d API calls, want 1", calls)
+	}
+}
+
+func TestAddCommentReaction(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/issues/comments/42/reactions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "content": "heart"}`)
+	})
+
+	if err := c.AddCommentReaction("o", "r", 42, "heart"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.AddCommentReaction("o", "r", 42, "love"); err == nil {
+		t.Error("expected an invalid reaction error")
+	}
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// ReleaseInfo describes a published release of a repo
+type ReleaseInfo struct {
+	TagName     string
+	Name        string
+	HTMLURL     string
+	PublishedAt time.Time
+}
+
+func newReleaseInfo(r *github.RepositoryRelease) ReleaseInfo {
+	return ReleaseInfo{
+		TagName:     r.GetTagName(),
+		Name:        r.GetName(),
+		HTMLURL:     r.GetHTMLURL(),
+		PublishedAt: r.GetPublishedAt().Time,
+	}
+}
+
+// GetLatestRelease returns the latest published release of a repo, or
+// ErrNoReleases if it doesn't have one
+func (c *Client) GetLatestRelease(org, repo string) (*ReleaseInfo, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	var r *github.RepositoryRelease
+	resp, err := c.do("Repositories.GetLatestRelease", func(ctx context.Context) (resp *github.Response, err error) {
+		r, resp, err = c.client.Repositories.GetLatestRelease(ctx, org, repo)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNoReleases, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch latest release for %s: %w", repo, newAPIError(resp, err))
+	}
+	info := newReleaseInfo(r)
+	return &info, nil
+}
+
+// ListReleases returns all releases of a repo, or ErrNoReleases if it doesn't have any
+func (c *Client) ListReleases(org, repo string) ([]ReleaseInfo, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.ListOptions{PerPage: c.PerPage}
+	all, resp, err := paginate(opt, func() ([]*github.RepositoryRelease, *github.Response, error) {
+		var page []*github.RepositoryRelease
+		resp, err := c.do("Repositories.ListReleases", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Repositories.ListReleases(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch releases for %s: %w", repo, newAPIError(resp, err))
+	}
+	var releases []ReleaseInfo
+	for _, r := range all {
+		releases = append(releases, newReleaseInfo(r))
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoReleases, repo)
+	}
+	return releases, nil
+}
+
+// ListReleasesSince returns the releases of a repo published after since,
+// newest first. Drafts, which haven't been published, are left out
+func (c *Client) ListReleasesSince(org, repo string, since time.Time) ([]ReleaseInfo, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	opt := &github.ListOptions{PerPage: c.PerPage}
+	releases, resp, err := paginate(opt, func() ([]*github.RepositoryRelease, *github.Response, error) {
+		var page []*github.RepositoryRelease
+		resp, err := c.do("Repositories.ListReleases", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Repositories.ListReleases(ctx, org, repo, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch releases for %s: %w", repo, newAPIError(resp, err))
+	}
+	var out []ReleaseInfo
+	for _, r := range releases {
+		if info := newReleaseInfo(r); info.PublishedAt.After(since) {
+			out = append(out, info)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].PublishedAt.After(out[j].PublishedAt)
+	})
+	return out, nil
+}
+
+// GenerateReleaseNotes drafts release notes for the commits after fromTag up
+// to toTag, as a chat message listing their subjects and authors. Merge
+// commits are left out, and commits that reference the same pull request are
+// listed together. Returns ErrRefNotFound if either tag doesn't exist
+func (c *Client) GenerateReleaseNotes(org, repo, fromTag, toTag string) (string, error) {
+	r := c.Repo(org, repo)
+	base, err := r.ResolveRef(fromTag)
+	if err != nil {
+		return "", err
+	}
+	head, err := r.ResolveRef(toTag)
+	if err != nil {
+		return "", err
+	}
+	result, err := c.CompareCommits(org, repo, base, head)
+	if err != nil {
+		return "", err
+	}
+	return FormatReleaseNotes(fromTag, toTag, result.Commits), nil
+}
+
+// ReleaseRequest holds the fields used to create a release. TagName is required
+type ReleaseRequest struct {
+	TagName         string
+	TargetCommitish string
+	Name            string
+	Body            string
+	Draft           bool
+	Prerelease      bool
+}
+
+// CreateRelease creates a release, and its tag if it doesn't exist yet, and
+// returns the URL of the new release. ErrTagExists is returned if a release
+// already uses the tag, and ErrRefNotFound if TargetCommitish doesn't exist
+func (c *Client) CreateRelease(org, repo string, opts ReleaseRequest) (string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return "", err
+	}
+	if opts.TagName == "" {
+		return "", errors.New("A tag name is required to create a release")
+	}
+	if msg, ok := c.dryRun("created release %s in %s/%s", opts.TagName, org, repo); ok {
+		return msg, nil
+	}
+	// Github only says the target is invalid, so check it first to name it
+	if opts.TargetCommitish != "" {
+		if _, err := c.ResolveRef(org, repo, opts.TargetCommitish); err != nil {
+			return "", err
+		}
+	}
+	release := &github.RepositoryRelease{
+		TagName:    github.String(opts.TagName),
+		Draft:      github.Bool(opts.Draft),
+		Prerelease: github.Bool(opts.Prerelease),
+	}
+	if opts.TargetCommitish != "" {
+		release.TargetCommitish = github.String(opts.TargetCommitish)
+	}
+	if opts.Name != "" {
+		release.Name = github.String(opts.Name)
+	}
+	if opts.Body != "" {
+		release.Body = github.String(opts.Body)
+	}
+	var r *github.RepositoryRelease
+	resp, err := c.do("Repositories.CreateRelease", func(ctx context.Context) (resp *github.Response, err error) {
+		r, resp, err = c.client.Repositories.CreateRelease(ctx, org, repo, release)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity && hasErrorCode(err, "already_exists") {
+		return "", fmt.Errorf("%w: %s", ErrTagExists, opts.TagName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when creating release: %w", newAPIError(resp, err))
+	}
+	return r.GetHTMLURL(), nil
+}
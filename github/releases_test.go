@@ -0,0 +1,202 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+func TestGetLatestRelease(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v1.2.0", "name": "Shiny", "html_url": "u", "published_at": "2017-06-01T12:00:00Z"}`)
+	})
+	mux.HandleFunc("/repos/o/empty/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	release, err := c.GetLatestRelease("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &ReleaseInfo{
+		TagName:     "v1.2.0",
+		Name:        "Shiny",
+		HTMLURL:     "u",
+		PublishedAt: time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(release, want) {
+		t.Errorf("got %+v, want %+v", release, want)
+	}
+
+	if _, err := c.GetLatestRelease("o", "empty"); !errors.Is(err, ErrNoReleases) {
+		t.Errorf("got error %v, want %v", err, ErrNoReleases)
+	}
+}
+
+func TestListReleases(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/releases", pagesHandler(
+		`[{"tag_name": "v1.2.0"}, {"tag_name": "v1.1.0"}]`,
+		`[{"tag_name": "v1.0.0"}]`,
+	))
+	mux.HandleFunc("/repos/o/empty/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	releases, err := c.ListReleases("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tags []string
+	for _, r := range releases {
+		tags = append(tags, r.TagName)
+	}
+	if want := []string{"v1.2.0", "v1.1.0", "v1.0.0"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("got tags %q, want %q", tags, want)
+	}
+
+	if _, err := c.ListReleases("o", "empty"); !errors.Is(err, ErrNoReleases) {
+		t.Errorf("got error %v, want %v", err, ErrNoReleases)
+	}
+}
+
+func TestListReleasesSince(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/releases", pagesHandler(
+		`[{"tag_name": "v1.2.0", "published_at": "2020-03-01T00:00:00Z"},
+		  {"tag_name": "v1.3.0-draft", "draft": true},
+		  {"tag_name": "v1.1.0", "published_at": "2020-01-15T00:00:00Z"}]`,
+		`[{"tag_name": "v1.1.1", "published_at": "2020-02-01T00:00:00Z"},
+		  {"tag_name": "v1.0.0", "published_at": "2019-12-01T00:00:00Z"}]`,
+	))
+
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	releases, err := c.ListReleasesSince("o", "r", since)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tags []string
+	for _, r := range releases {
+		tags = append(tags, r.TagName)
+	}
+	if want := []string{"v1.2.0", "v1.1.1", "v1.1.0"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("got tags %q, want %q", tags, want)
+	}
+
+	releases, err = c.ListReleasesSince("o", "r", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(releases) != 0 {
+		t.Errorf("got %d releases after the last one, want none", len(releases))
+	}
+}
+
+func TestGenerateReleaseNotes(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	for tag, sha := range map[string]string{"v1.2": "aaa111", "v1.3": "bbb222"} {
+		sha := sha
+		mux.HandleFunc("/repos/o/r/commits/"+tag, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, sha)
+		})
+	}
+	mux.HandleFunc("/repos/o/r/commits/v9.9", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/o/r/compare/aaa111...bbb222", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "ahead", "ahead_by": 3, "commits": [
+			{"sha": "a1", "author": {"login": "deckard"}, "commit": {"message": "Add the Voight-Kampff test (#12)\n\nDetails"}, "parents": [{"sha": "aaa111"}]},
+			{"sha": "b2", "author": {"login": "deckard"}, "commit": {"message": "Merge pull request #12 from o/vk"}, "parents": [{"sha": "aaa111"}, {"sha": "a1"}]},
+			{"sha": "c3", "commit": {"message": "Update origami docs", "author": {"name": "Gaff"}}, "parents": [{"sha": "b2"}]}
+		]}`)
+	})
+
+	got, err := c.GenerateReleaseNotes("o", "r", "v1.2", "v1.3")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "*Changes from v1.2 to v1.3:*\n• Add the Voight-Kampff test (#12) @deckard\n• Update origami docs Gaff"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := c.GenerateReleaseNotes("o", "r", "v1.2", "v9.9"); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrRefNotFound)
+	}
+}
+
+func TestCreateRelease(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/commits/master", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "6dcb09b5b57875f334f61aebed695e2e4193db5e")
+	})
+	mux.HandleFunc("/repos/o/r/releases", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("got method %s, want POST", r.Method)
+		}
+		var got github.RepositoryRelease
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.GetTagName() == "v1.0.0" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "Validation Failed", "errors": [{"resource": "Release", "code": "already_exists", "field": "tag_name"}]}`)
+			return
+		}
+		want := github.RepositoryRelease{
+			TagName:         github.String("v1.3.0"),
+			TargetCommitish: github.String("master"),
+			Name:            github.String("Thirteen"),
+			Body:            github.String("notes"),
+			Draft:           github.Bool(false),
+			Prerelease:      github.Bool(true),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got release %+v, want %+v", got, want)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"html_url": "https://github.com/o/r/releases/tag/v1.3.0"}`)
+	})
+
+	url, err := c.CreateRelease("o", "r", ReleaseRequest{
+		TagName:         "v1.3.0",
+		TargetCommitish: "master",
+		Name:            "Thirteen",
+		Body:            "notes",
+		Prerelease:      true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "https://github.com/o/r/releases/tag/v1.3.0"; url != want {
+		t.Errorf("got %q, want %q", url, want)
+	}
+
+	if _, err := c.CreateRelease("o", "r", ReleaseRequest{TagName: "v1.0.0"}); !errors.Is(err, ErrTagExists) {
+		t.Errorf("got error %v, want %v", err, ErrTagExists)
+	}
+	if _, err := c.CreateRelease("o", "r", ReleaseRequest{}); err == nil {
+		t.Error("expected an error for a missing tag name, got nil")
+	}
+	// no handler for the target, so it's not found
+	if _, err := c.CreateRelease("o", "r", ReleaseRequest{TagName: "v1.4.0", TargetCommitish: "missing"}); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrRefNotFound)
+	}
+}
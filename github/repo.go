@@ -0,0 +1,677 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// ErrInvalidRepo is returned when a repo isn't in "org/repo" form
+var ErrInvalidRepo = errors.New("Github repo must be in the form org/repo")
+
+// SplitRepo splits "org/repo", as typed in chat, into its org and repo
+func SplitRepo(full string) (org, repo string, err error) {
+	parts := strings.Split(full, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("%w, got %q", ErrInvalidRepo, full)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Repo is a Github repo, bound to the Client used to query it. It saves
+// passing org and repo to every call:
+//
+//	repo, err := client.ParseRepo("handwritingio/deckard-bot")
+//	branch, err := repo.Branch("master")
+type Repo struct {
+	Org  string
+	Name string
+
+	c *Client
+}
+
+// Repo returns org/name as a Repo queried with c
+func (c *Client) Repo(org, name string) Repo {
+	return Repo{Org: org, Name: name, c: c}
+}
+
+// ParseRepo parses "org/repo", as typed in chat, into a Repo queried with c
+func (c *Client) ParseRepo(full string) (Repo, error) {
+	org, name, err := SplitRepo(full)
+	if err != nil {
+		return Repo{}, err
+	}
+	return c.Repo(org, name), nil
+}
+
+func (r Repo) String() string {
+	return r.Org + "/" + r.Name
+}
+
+// RepoInfo describes a Github repo
+type RepoInfo struct {
+	FullName      string
+	Description   string
+	DefaultBranch string
+	Private       bool
+	HTMLURL       string
+	// Archived and Disabled repos are read only
+	Archived bool
+	Disabled bool
+}
+
+// Get returns the repo's details, or ErrRepoNotFound if it doesn't exist or
+// the Client can't see it
+func (r Repo) Get() (*RepoInfo, error) {
+	repo, err := r.fetch()
+	if err != nil {
+		return nil, err
+	}
+	return &RepoInfo{
+		FullName:      repo.GetFullName(),
+		Description:   repo.GetDescription(),
+		DefaultBranch: repo.GetDefaultBranch(),
+		Private:       repo.GetPrivate(),
+		HTMLURL:       repo.GetHTMLURL(),
+		Archived:      repo.GetArchived(),
+		Disabled:      repo.Disabled,
+	}, nil
+}
+
+// writable returns the repo's details, or ErrRepoArchived if Github won't
+// accept changes to it. It isn't cached, as a repo can be archived at any time
+func (r Repo) writable() (*RepoInfo, error) {
+	info, err := r.Get()
+	if err != nil {
+		return nil, err
+	}
+	if info.Archived || info.Disabled {
+		return nil, fmt.Errorf("%w: %s", ErrRepoArchived, r)
+	}
+	return info, nil
+}
+
+// DefaultBranch returns the name of the repo's default branch, e.g. "main"
+// or "master"
+func (r Repo) DefaultBranch() (string, error) {
+	info, err := r.Get()
+	if err != nil {
+		return "", err
+	}
+	return info.DefaultBranch, nil
+}
+
+// GetDefaultBranch returns the name of a repo's default branch
+func (c *Client) GetDefaultBranch(org, repo string) (string, error) {
+	return c.Repo(org, repo).DefaultBranch()
+}
+
+// RepoStats is an overview of a repo's activity
+type RepoStats struct {
+	Stars         int
+	Forks         int
+	OpenIssues    int
+	DefaultBranch string
+	// Language is the repo's primary language, if Github could detect one
+	Language string
+	PushedAt time.Time
+}
+
+// Stats returns an overview of the repo, or ErrRepoNotFound if it doesn't
+// exist or the Client can't see it
+func (r Repo) Stats() (*RepoStats, error) {
+	repo, err := r.fetch()
+	if err != nil {
+		return nil, err
+	}
+	return &RepoStats{
+		Stars:         repo.GetStargazersCount(),
+		Forks:         repo.GetForksCount(),
+		OpenIssues:    repo.GetOpenIssuesCount(),
+		DefaultBranch: repo.GetDefaultBranch(),
+		Language:      repo.GetLanguage(),
+		PushedAt:      repo.GetPushedAt().Time,
+	}, nil
+}
+
+// GetRepoStats returns an overview of a repo's activity
+func (c *Client) GetRepoStats(org, repo string) (*RepoStats, error) {
+	return c.Repo(org, repo).Stats()
+}
+
+// repository is a github.Repository with the disabled flag, which go-github
+// doesn't decode
+type repository struct {
+	*github.Repository
+	Disabled bool `json:"disabled"`
+}
+
+// fetch gets the repo from Github. Github responds to private repos the
+// Client can't access with a 404, so the error says so
+func (r Repo) fetch() (*repository, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return nil, err
+	}
+	req, err := r.c.client.NewRequest("GET", fmt.Sprintf("repos/%s/%s", r.Org, r.Name), nil)
+	if err != nil {
+		return nil, err
+	}
+	repo := &repository{}
+	resp, err := r.c.do("Repositories.Get", func(ctx context.Context) (*github.Response, error) {
+		return r.c.client.Do(ctx, req, repo)
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s, or it's private and the Client doesn't have access", ErrRepoNotFound, r)
+	}
+	if err != nil {
+		return nil, newAPIError(resp, err)
+	}
+	return repo, nil
+}
+
+// BranchInfo describes a branch of a repo
+type BranchInfo struct {
+	Name      string
+	CommitSHA string
+	Protected bool
+}
+
+// Branch returns the named branch, or ErrBranchNotFound if it doesn't exist
+func (r Repo) Branch(name string) (*BranchInfo, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return nil, err
+	}
+	var b *github.Branch
+	resp, err := r.c.do("Repositories.GetBranch", func(ctx context.Context) (resp *github.Response, err error) {
+		b, resp, err = r.c.client.Repositories.GetBranch(ctx, r.Org, r.Name, name)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s in repo %s", ErrBranchNotFound, name, r.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch branch %s for %s: %w", name, r.Name, newAPIError(resp, err))
+	}
+	info := newBranchInfo(b)
+	return &info, nil
+}
+
+func newBranchInfo(b *github.Branch) BranchInfo {
+	return BranchInfo{
+		Name:      b.GetName(),
+		CommitSHA: b.GetCommit().GetSHA(),
+		Protected: b.GetProtected(),
+	}
+}
+
+// Branches returns all branches of the repo
+func (r Repo) Branches() ([]BranchInfo, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return nil, err
+	}
+	opt := &github.ListOptions{PerPage: r.c.PerPage}
+	all, resp, err := paginate(opt, func() ([]*github.Branch, *github.Response, error) {
+		var page []*github.Branch
+		resp, err := r.c.do("Repositories.ListBranches", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = r.c.client.Repositories.ListBranches(ctx, r.Org, r.Name, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRepoNotFound, r.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch branches for %s: %w", r.Name, newAPIError(resp, err))
+	}
+	var branches []BranchInfo
+	for _, b := range all {
+		branches = append(branches, newBranchInfo(b))
+	}
+	return branches, nil
+}
+
+// ListBranches returns all branches of a repo
+func (c *Client) ListBranches(org, repo string) ([]BranchInfo, error) {
+	return c.Repo(org, repo).Branches()
+}
+
+// TagInfo describes a tag of a repo
+type TagInfo struct {
+	Name      string
+	CommitSHA string
+}
+
+// Tags returns all tags of the repo, newest version first. Github doesn't
+// return tag dates, so tags are sorted by name with numbers compared by
+// value, e.g. v1.10 is newer than v1.9
+func (r Repo) Tags() ([]TagInfo, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return nil, err
+	}
+	opt := &github.ListOptions{PerPage: r.c.PerPage}
+	all, resp, err := paginate(opt, func() ([]*github.RepositoryTag, *github.Response, error) {
+		var page []*github.RepositoryTag
+		resp, err := r.c.do("Repositories.ListTags", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = r.c.client.Repositories.ListTags(ctx, r.Org, r.Name, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRepoNotFound, r.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch tags for %s: %w", r.Name, newAPIError(resp, err))
+	}
+	var tags []TagInfo
+	for _, t := range all {
+		tags = append(tags, TagInfo{Name: t.GetName(), CommitSHA: t.GetCommit().GetSHA()})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return naturalLess(tags[j].Name, tags[i].Name)
+	})
+	return tags, nil
+}
+
+// ListTags returns all tags of a repo, newest version first
+func (c *Client) ListTags(org, repo string) ([]TagInfo, error) {
+	return c.Repo(org, repo).Tags()
+}
+
+// naturalLess compares a and b treating runs of digits as numbers
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		ca, cb := leadingChunk(a), leadingChunk(b)
+		a, b = a[len(ca):], b[len(cb):]
+		if ca == cb {
+			continue
+		}
+		na, errA := strconv.Atoi(ca)
+		nb, errB := strconv.Atoi(cb)
+		if errA == nil && errB == nil && na != nb {
+			return na < nb
+		}
+		return ca < cb
+	}
+	return len(a) < len(b)
+}
+
+// leadingChunk returns the leading run of digits or non-digits in s
+func leadingChunk(s string) string {
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	digits := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i]
+}
+
+// ResolveRef returns the full commit SHA that ref, a branch, tag or
+// abbreviated SHA, points to. Returns ErrRefNotFound if it doesn't match a
+// commit
+func (r Repo) ResolveRef(ref string) (string, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return "", err
+	}
+	var sha string
+	resp, err := r.c.do("Repositories.GetCommitSHA1", func(ctx context.Context) (resp *github.Response, err error) {
+		sha, resp, err = r.c.client.Repositories.GetCommitSHA1(ctx, r.Org, r.Name, ref, "")
+		return
+	})
+	// Github responds with a 422 for refs that look like a SHA but aren't one
+	if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+		return "", fmt.Errorf("%w: %s in repo %s", ErrRefNotFound, ref, r.Name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Could not resolve %s in %s: %w", ref, r.Name, newAPIError(resp, err))
+	}
+	return sha, nil
+}
+
+// ResolveRef returns the full commit SHA that ref, a branch, tag or
+// abbreviated SHA, points to in a repo
+func (c *Client) ResolveRef(org, repo, ref string) (string, error) {
+	return c.Repo(org, repo).ResolveRef(ref)
+}
+
+// CreateBranch creates newBranch from fromRef, a branch, tag or commit SHA,
+// and returns the new branch's URL. Returns ErrRefNotFound if fromRef
+// doesn't exist and ErrBranchExists if newBranch already does
+func (r Repo) CreateBranch(newBranch, fromRef string) (string, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return "", err
+	}
+	if msg, ok := r.c.dryRun("created branch %s from %s in %s", newBranch, fromRef, r); ok {
+		return msg, nil
+	}
+	info, err := r.writable()
+	if err != nil {
+		return "", err
+	}
+	sha, err := r.ResolveRef(fromRef)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.c.do("Git.CreateRef", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = r.c.client.Git.CreateRef(ctx, r.Org, r.Name, &github.Reference{
+			Ref:    github.String("refs/heads/" + newBranch),
+			Object: &github.GitObject{SHA: github.String(sha)},
+		})
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+		return "", fmt.Errorf("%w: %s in repo %s: %s", ErrBranchExists, newBranch, r.Name, errorMessage(err))
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when creating branch %s: %w", newBranch, newAPIError(resp, err))
+	}
+	r.c.cache.set(branchCacheKey(r.Org, r.Name, newBranch), true, r.c.CacheTTL)
+	return info.HTMLURL + "/tree/" + newBranch, nil
+}
+
+// CreateBranch creates newBranch in a repo from fromRef, a branch, tag or
+// commit SHA, and returns the new branch's URL
+func (c *Client) CreateBranch(org, repo, newBranch, fromRef string) (string, error) {
+	return c.Repo(org, repo).CreateBranch(newBranch, fromRef)
+}
+
+// DeleteBranch deletes a branch of the repo. It refuses to delete the
+// default branch, returning ErrDefaultBranch, or a protected branch,
+// returning ErrBranchProtected
+func (r Repo) DeleteBranch(branch string) error {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return err
+	}
+	if _, ok := r.c.dryRun("deleted branch %s in %s", branch, r); ok {
+		return nil
+	}
+	info, err := r.Get()
+	if err != nil {
+		return err
+	}
+	if branch == info.DefaultBranch {
+		return fmt.Errorf("%w: %s in repo %s", ErrDefaultBranch, branch, r.Name)
+	}
+	b, err := r.Branch(branch)
+	if err != nil {
+		return err
+	}
+	if b.Protected {
+		return fmt.Errorf("%w: %s in repo %s", ErrBranchProtected, branch, r.Name)
+	}
+	resp, err := r.c.do("Git.DeleteRef", func(ctx context.Context) (*github.Response, error) {
+		return r.c.client.Git.DeleteRef(ctx, r.Org, r.Name, "heads/"+branch)
+	})
+	if err != nil {
+		return fmt.Errorf("Error occurred when deleting branch %s: %w", branch, newAPIError(resp, err))
+	}
+	r.c.cache.set(branchCacheKey(r.Org, r.Name, branch), false, r.c.CacheTTL)
+	return nil
+}
+
+// DeleteBranch deletes a branch of a repo, unless it's the default branch
+// or protected
+func (c *Client) DeleteBranch(org, repo, branch string) error {
+	return c.Repo(org, repo).DeleteBranch(branch)
+}
+
+// Contributor is someone who has committed to a repo. Anonymous
+// contributors, whose commits aren't linked to a Github account, have no Login
+type Contributor struct {
+	Login         string
+	Contributions int
+	Anonymous     bool
+}
+
+// Contributors returns everyone who has committed to the repo, most
+// contributions first. Anonymous contributors are only included if
+// includeAnonymous is set
+func (r Repo) Contributors(includeAnonymous bool) ([]Contributor, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return nil, err
+	}
+	opt := &github.ListContributorsOptions{
+		ListOptions: github.ListOptions{PerPage: r.c.PerPage},
+	}
+	if includeAnonymous {
+		opt.Anon = "true"
+	}
+	all, resp, err := paginate(&opt.ListOptions, func() ([]*github.Contributor, *github.Response, error) {
+		var page []*github.Contributor
+		resp, err := r.c.do("Repositories.ListContributors", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = r.c.client.Repositories.ListContributors(ctx, r.Org, r.Name, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRepoNotFound, r.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch contributors for %s: %w", r.Name, newAPIError(resp, err))
+	}
+	var contributors []Contributor
+	for _, con := range all {
+		contributors = append(contributors, Contributor{
+			Login:         con.GetLogin(),
+			Contributions: con.GetContributions(),
+			Anonymous:     con.GetType() == "Anonymous",
+		})
+	}
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return contributors[i].Contributions > contributors[j].Contributions
+	})
+	return contributors, nil
+}
+
+// ListContributors returns everyone with a Github account who has committed
+// to a repo, most contributions first. Use Repo.Contributors to include
+// anonymous contributors
+func (c *Client) ListContributors(org, repo string) ([]Contributor, error) {
+	return c.Repo(org, repo).Contributors(false)
+}
+
+// Star stars the repo as the Client's user. Returns ErrAuthRequired without
+// an API key
+func (r Repo) Star() error {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return err
+	}
+	if !r.c.authenticated {
+		return fmt.Errorf("Can't star %s: %w", r, ErrAuthRequired)
+	}
+	if _, ok := r.c.dryRun("starred %s", r); ok {
+		return nil
+	}
+	resp, err := r.c.do("Activity.Star", func(ctx context.Context) (*github.Response, error) {
+		return r.c.client.Activity.Star(ctx, r.Org, r.Name)
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrRepoNotFound, r.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when starring %s: %w", r, newAPIError(resp, err))
+	}
+	return nil
+}
+
+// Unstar unstars the repo as the Client's user. Unstarring a repo that
+// isn't starred does nothing. Returns ErrAuthRequired without an API key
+func (r Repo) Unstar() error {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return err
+	}
+	if !r.c.authenticated {
+		return fmt.Errorf("Can't unstar %s: %w", r, ErrAuthRequired)
+	}
+	if _, ok := r.c.dryRun("unstarred %s", r); ok {
+		return nil
+	}
+	resp, err := r.c.do("Activity.Unstar", func(ctx context.Context) (*github.Response, error) {
+		return r.c.client.Activity.Unstar(ctx, r.Org, r.Name)
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when unstarring %s: %w", r, newAPIError(resp, err))
+	}
+	return nil
+}
+
+// StarRepo stars a repo as the Client's user
+func (c *Client) StarRepo(org, repo string) error {
+	return c.Repo(org, repo).Star()
+}
+
+// UnstarRepo unstars a repo as the Client's user
+func (c *Client) UnstarRepo(org, repo string) error {
+	return c.Repo(org, repo).Unstar()
+}
+
+// Fork forks the repo into intoOrg, or to the Client's user if intoOrg is
+// empty. Github creates forks in the background, so the returned message
+// links to where the fork will be once it's ready
+func (r Repo) Fork(intoOrg string) (string, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return "", err
+	}
+	if intoOrg != "" {
+		if err := r.c.allowOrg(intoOrg); err != nil {
+			return "", err
+		}
+	}
+	if !r.c.authenticated {
+		return "", fmt.Errorf("Can't fork %s: %w", r, ErrAuthRequired)
+	}
+	destination := "your account"
+	if intoOrg != "" {
+		destination = intoOrg
+	}
+	if msg, ok := r.c.dryRun("forked %s into %s", r, destination); ok {
+		return msg, nil
+	}
+	var fork *github.Repository
+	resp, err := r.c.do("Repositories.CreateFork", func(ctx context.Context) (resp *github.Response, err error) {
+		fork, resp, err = r.c.client.Repositories.CreateFork(ctx, r.Org, r.Name, &github.RepositoryCreateForkOptions{
+			Organization: intoOrg,
+		})
+		return
+	})
+	if _, ok := err.(*github.AcceptedError); ok {
+		err = nil
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrRepoNotFound, r.Name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when forking %s: %w", r, newAPIError(resp, err))
+	}
+	return fmt.Sprintf("*Fork of %s is being created*\n%s", r, fork.GetHTMLURL()), nil
+}
+
+// ForkRepo forks a repo into intoOrg, or to the Client's user if intoOrg is
+// empty
+func (c *Client) ForkRepo(org, repo, intoOrg string) (string, error) {
+	return c.Repo(org, repo).Fork(intoOrg)
+}
+
+// Rename renames the repo to newName and returns the renamed repo's URL.
+// Github redirects the old name for a while, but it's free for a new repo to
+// take. Returns ErrAdminRequired if the Client isn't an admin of the repo
+func (r Repo) Rename(newName string) (string, error) {
+	if err := r.c.allowOrg(r.Org); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(newName) == "" {
+		return "", errors.New("A new name is required to rename a repo")
+	}
+	if !r.c.authenticated {
+		return "", fmt.Errorf("Can't rename %s: %w", r, ErrAuthRequired)
+	}
+	if msg, ok := r.c.dryRun("renamed %s to %s", r, newName); ok {
+		return msg, nil
+	}
+	var repo *github.Repository
+	resp, err := r.c.do("Repositories.Edit", func(ctx context.Context) (resp *github.Response, err error) {
+		repo, resp, err = r.c.client.Repositories.Edit(ctx, r.Org, r.Name, &github.Repository{
+			Name: github.String(newName),
+		})
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrRepoNotFound, r.Name)
+	}
+	if resp != nil && resp.StatusCode == http.StatusForbidden {
+		if _, ok := retryWait(resp, err); !ok {
+			return "", fmt.Errorf("%w: can't rename %s: %s", ErrAdminRequired, r, errorMessage(err))
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error occurred when renaming %s: %w", r, newAPIError(resp, err))
+	}
+	r.c.cache.set(repoCacheKey(r.Org, r.Name), false, r.c.CacheTTL)
+	return repo.GetHTMLURL(), nil
+}
+
+// RenameRepo renames a repo to newName, which needs admin rights on it
+func (c *Client) RenameRepo(org, repo, newName string) (string, error) {
+	return c.Repo(org, repo).Rename(newName)
+}
+
+// RepoSummary is a short description of a repo, as listed for an org
+type RepoSummary struct {
+	Name          string
+	Description   string
+	Private       bool
+	DefaultBranch string
+}
+
+// ListOrgRepos returns the repos of an org of the given type, which must be
+// "all", "public", "private", "forks" or "sources". An empty type means "all"
+func (c *Client) ListOrgRepos(org, repoType string) ([]RepoSummary, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	switch repoType {
+	case "":
+		repoType = "all"
+	case "all", "public", "private", "forks", "sources":
+	default:
+		return nil, fmt.Errorf("Invalid repo type %q: must be all, public, private, forks or sources", repoType)
+	}
+	opt := &github.RepositoryListByOrgOptions{
+		Type:        repoType,
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	all, resp, err := paginate(&opt.ListOptions, func() ([]*github.Repository, *github.Response, error) {
+		var page []*github.Repository
+		resp, err := c.do("Repositories.ListByOrg", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Repositories.ListByOrg(ctx, org, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch repos for %s: %w", org, newAPIError(resp, err))
+	}
+	var repos []RepoSummary
+	for _, r := range all {
+		repos = append(repos, RepoSummary{
+			Name:          r.GetName(),
+			Description:   r.GetDescription(),
+			Private:       r.GetPrivate(),
+			DefaultBranch: r.GetDefaultBranch(),
+		})
+	}
+	return repos, nil
+}
@@ -0,0 +1,534 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitRepo(t *testing.T) {
+	org, repo, err := SplitRepo("handwritingio/deckard-bot")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if org != "handwritingio" || repo != "deckard-bot" {
+		t.Errorf("got %q, %q, want handwritingio, deckard-bot", org, repo)
+	}
+
+	for _, bad := range []string{"", "justrepo", "a/b/c", "/repo", "org/", "/"} {
+		if _, _, err := SplitRepo(bad); !errors.Is(err, ErrInvalidRepo) {
+			t.Errorf("got error %v for %q, want %v", err, bad, ErrInvalidRepo)
+		}
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	c := NewClient("")
+	r, err := c.ParseRepo("handwritingio/deckard-bot")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.Org != "handwritingio" || r.Name != "deckard-bot" || r.String() != "handwritingio/deckard-bot" {
+		t.Errorf("got %+v", r)
+	}
+	if _, err := c.ParseRepo("a/b/c"); !errors.Is(err, ErrInvalidRepo) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidRepo)
+	}
+}
+
+func TestRepoGet(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"full_name": "o/r",
+			"description": "A repo",
+			"default_branch": "main",
+			"private": true,
+			"html_url": "https://github.com/o/r"
+		}`)
+	})
+
+	got, err := c.Repo("o", "r").Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &RepoInfo{
+		FullName:      "o/r",
+		Description:   "A repo",
+		DefaultBranch: "main",
+		Private:       true,
+		HTMLURL:       "https://github.com/o/r",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := c.Repo("o", "missing").Get(); !errors.Is(err, ErrRepoNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrRepoNotFound)
+	}
+}
+
+func TestRepoBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/branches/master", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "master", "commit": {"sha": "abc123"}, "protected": true}`)
+	})
+
+	r, err := c.ParseRepo("o/r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Branch("master")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &BranchInfo{Name: "master", CommitSHA: "abc123", Protected: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := r.Branch("missing"); !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrBranchNotFound)
+	}
+}
+
+func TestListBranches(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/branches", pagesHandler(
+		`[{"name": "master", "commit": {"sha": "a1"}, "protected": true}, {"name": "develop", "commit": {"sha": "b2"}}]`,
+		`[{"name": "feature", "commit": {"sha": "c3"}, "protected": false}]`,
+	))
+
+	got, err := c.ListBranches("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []BranchInfo{
+		{Name: "master", CommitSHA: "a1", Protected: true},
+		{Name: "develop", CommitSHA: "b2"},
+		{Name: "feature", CommitSHA: "c3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListTags(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/tags", pagesHandler(
+		`[{"name": "v1.9.0", "commit": {"sha": "a1"}}, {"name": "v1.10.0", "commit": {"sha": "b2"}}]`,
+		`[{"name": "v2.0.0", "commit": {"sha": "c3"}}, {"name": "v1.10.1", "commit": {"sha": "d4"}}]`,
+	))
+
+	got, err := c.ListTags("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []TagInfo{
+		{Name: "v2.0.0", CommitSHA: "c3"},
+		{Name: "v1.10.1", CommitSHA: "d4"},
+		{Name: "v1.10.0", CommitSHA: "b2"},
+		{Name: "v1.9.0", CommitSHA: "a1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"full_name": "o/r", "html_url": "https://github.com/o/r"}`)
+	})
+	mux.HandleFunc("/repos/o/r/commits/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/commits/v1.0":
+			fmt.Fprint(w, "abc123")
+		case "/repos/o/r/commits/master":
+			fmt.Fprint(w, "def456")
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "No commit found for SHA: nope"}`)
+		}
+	})
+	mux.HandleFunc("/repos/o/r/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		var got struct{ Ref, SHA string }
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Ref == "refs/heads/exists" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "Reference already exists"}`)
+			return
+		}
+		if got.Ref != "refs/heads/hotfix" || got.SHA != "abc123" {
+			t.Errorf("got request %+v, want refs/heads/hotfix at abc123", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"ref": "refs/heads/hotfix", "object": {"sha": "abc123"}}`)
+	})
+
+	got, err := c.CreateBranch("o", "r", "hotfix", "v1.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "https://github.com/o/r/tree/hotfix"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := c.CreateBranch("o", "r", "exists", "master"); !errors.Is(err, ErrBranchExists) {
+		t.Errorf("got error %v, want %v", err, ErrBranchExists)
+	}
+	if _, err := c.CreateBranch("o", "r", "hotfix", "nope"); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrRefNotFound)
+	}
+}
+
+func TestDeleteBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"full_name": "o/r", "default_branch": "main"}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/release", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "release", "commit": {"sha": "a1"}, "protected": true}`)
+	})
+	mux.HandleFunc("/repos/o/r/branches/old", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "old", "commit": {"sha": "b2"}, "protected": false}`)
+	})
+	deleted := []string{}
+	mux.HandleFunc("/repos/o/r/git/refs/heads/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("got method %s, want DELETE", r.Method)
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.DeleteBranch("o", "r", "main"); !errors.Is(err, ErrDefaultBranch) {
+		t.Errorf("got error %v, want %v", err, ErrDefaultBranch)
+	}
+	if err := c.DeleteBranch("o", "r", "release"); !errors.Is(err, ErrBranchProtected) {
+		t.Errorf("got error %v, want %v", err, ErrBranchProtected)
+	}
+	if err := c.DeleteBranch("o", "r", "old"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if want := []string{"/repos/o/r/git/refs/heads/old"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deletes %v, want %v", deleted, want)
+	}
+}
+
+func TestContributors(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	pages := map[string]http.HandlerFunc{
+		"": pagesHandler(
+			`[{"login": "rachael", "type": "User", "contributions": 5}, {"login": "deckard", "type": "User", "contributions": 12}]`,
+			`[{"login": "gaff", "type": "User", "contributions": 7}]`,
+		),
+		"true": pagesHandler(
+			`[{"login": "deckard", "type": "User", "contributions": 12}, {"type": "Anonymous", "contributions": 9}]`,
+		),
+	}
+	mux.HandleFunc("/repos/o/r/contributors", func(w http.ResponseWriter, r *http.Request) {
+		pages[r.URL.Query().Get("anon")](w, r)
+	})
+
+	got, err := c.ListContributors("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []Contributor{
+		{Login: "deckard", Contributions: 12},
+		{Login: "gaff", Contributions: 7},
+		{Login: "rachael", Contributions: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got, err = c.Repo("o", "r").Contributors(true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want = []Contributor{
+		{Login: "deckard", Contributions: 12},
+		{Contributions: 9, Anonymous: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestStarRepo(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	if err := c.StarRepo("o", "r"); !errors.Is(err, ErrAuthRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAuthRequired)
+	}
+	if err := c.UnstarRepo("o", "r"); !errors.Is(err, ErrAuthRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAuthRequired)
+	}
+
+	c.authenticated = true
+	var methods []string
+	mux.HandleFunc("/user/starred/o/r", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/user/starred/o/unstarred", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	if err := c.StarRepo("o", "r"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := c.UnstarRepo("o", "r"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if want := []string{http.MethodPut, http.MethodDelete}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("got methods %v, want %v", methods, want)
+	}
+	if err := c.UnstarRepo("o", "unstarred"); err != nil {
+		t.Errorf("got error %v unstarring a repo that isn't starred, want nil", err)
+	}
+}
+
+func TestForkRepo(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	mux.HandleFunc("/repos/o/r/forks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("got method %s, want POST", r.Method)
+		}
+		org := r.URL.Query().Get("organization")
+		if org == "" {
+			org = "deckard"
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"full_name": "%s/r", "html_url": "https://github.com/%s/r"}`, org, org)
+	})
+
+	tests := []struct {
+		intoOrg string
+		want    string
+	}{
+		{"", "*Fork of o/r is being created*\nhttps://github.com/deckard/r"},
+		{"bots", "*Fork of o/r is being created*\nhttps://github.com/bots/r"},
+	}
+	for _, tt := range tests {
+		got, err := c.ForkRepo("o", "r", tt.intoOrg)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRenameRepo(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("got method %s, want PATCH", r.Method)
+		}
+		var got map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if want := map[string]interface{}{"name": "r2"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got request %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"name": "r2", "html_url": "https://github.com/o/r2"}`)
+	})
+	mux.HandleFunc("/repos/o/locked", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Must have admin rights to Repository."}`, http.StatusForbidden)
+	})
+
+	got, err := c.RenameRepo("o", "r", "r2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "https://github.com/o/r2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := c.RenameRepo("o", "locked", "r2"); !errors.Is(err, ErrAdminRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAdminRequired)
+	}
+	if _, err := c.RenameRepo("o", "r", " "); err == nil {
+		t.Error("expected an error for an empty name, got nil")
+	}
+}
+
+func TestGetRepoStats(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": 1296269,
+			"name": "r",
+			"full_name": "o/r",
+			"owner": {"login": "o", "type": "Organization"},
+			"private": false,
+			"html_url": "https://github.com/o/r",
+			"description": "A repo",
+			"fork": false,
+			"language": "Go",
+			"forks_count": 9,
+			"stargazers_count": 80,
+			"watchers_count": 80,
+			"size": 108,
+			"default_branch": "master",
+			"open_issues_count": 3,
+			"pushed_at": "2019-01-26T19:06:43Z",
+			"created_at": "2011-01-26T19:01:12Z",
+			"updated_at": "2019-01-26T19:14:43Z"
+		}`)
+	})
+	mux.HandleFunc("/repos/o/private", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	got, err := c.GetRepoStats("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &RepoStats{
+		Stars:         80,
+		Forks:         9,
+		OpenIssues:    3,
+		DefaultBranch: "master",
+		Language:      "Go",
+		PushedAt:      time.Date(2019, 1, 26, 19, 6, 43, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	_, err = c.GetRepoStats("o", "private")
+	if !errors.Is(err, ErrRepoNotFound) {
+		t.Fatalf("got error %v, want %v", err, ErrRepoNotFound)
+	}
+	wantErr := "Github repo not found: o/private, or it's private and the Client doesn't have access"
+	if err.Error() != wantErr {
+		t.Errorf("got error %q, want %q", err.Error(), wantErr)
+	}
+}
+
+func TestListOrgRepos(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	pages := pagesHandler(
+		`[{"name": "a", "description": "First", "private": true, "default_branch": "master"}]`,
+		`[{"name": "b", "default_branch": "main"}]`,
+	)
+	mux.HandleFunc("/orgs/o/repos", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("type"); got != "sources" {
+			t.Errorf("got type %q, want sources", got)
+		}
+		pages(w, r)
+	})
+
+	got, err := c.ListOrgRepos("o", "sources")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []RepoSummary{
+		{Name: "a", Description: "First", Private: true, DefaultBranch: "master"},
+		{Name: "b", DefaultBranch: "main"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := c.ListOrgRepos("o", "everything"); err == nil {
+		t.Error("expected an invalid type error")
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	const sha = "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	mux.HandleFunc("/repos/o/r/commits/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/vnd.github.v3.sha" {
+			t.Errorf("got Accept %q, want the SHA media type", got)
+		}
+		switch r.URL.Path {
+		case "/repos/o/r/commits/master", "/repos/o/r/commits/v1.0", "/repos/o/r/commits/6dcb09b":
+			fmt.Fprint(w, sha)
+		case "/repos/o/r/commits/deadbee":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "No commit found for SHA: deadbee"}`)
+		default:
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}
+	})
+
+	for _, ref := range []string{"master", "v1.0", "6dcb09b"} {
+		got, err := c.ResolveRef("o", "r", ref)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", ref, err)
+			continue
+		}
+		if got != sha {
+			t.Errorf("%s: got %q, want %q", ref, got, sha)
+		}
+	}
+	for _, ref := range []string{"deadbee", "missing"} {
+		if _, err := c.ResolveRef("o", "r", ref); !errors.Is(err, ErrRefNotFound) {
+			t.Errorf("%s: got error %v, want %v", ref, err, ErrRefNotFound)
+		}
+	}
+}
+
+func TestGetDefaultBranch(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/new", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "new", "default_branch": "main"}`)
+	})
+	mux.HandleFunc("/repos/o/old", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "old", "default_branch": "master"}`)
+	})
+
+	for repo, want := range map[string]string{"new": "main", "old": "master"} {
+		got, err := c.GetDefaultBranch("o", repo)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", repo, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", repo, got, want)
+		}
+	}
+}
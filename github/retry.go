@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a rate limited request is
+	// retried by a Client from NewClient
+	DefaultMaxRetries = 3
+	// DefaultMaxRetryWait is the longest a Client from NewClient will wait
+	// before retrying a rate limited request
+	DefaultMaxRetryWait = time.Minute
+	// DefaultCallTimeout is the CallTimeout of a Client from NewClient. It's
+	// shorter than DefaultTimeout so it's the one that ends a stuck API call
+	DefaultCallTimeout = 20 * time.Second
+
+	// defaultAbuseRetryWait is used when Github's abuse detection doesn't
+	// send a Retry-After header
+	defaultAbuseRetryWait = time.Minute
+)
+
+// do calls fn with the Client's context, retrying when Github responds with
+// a rate limit error. fn should make exactly one Github API call, to the
+// go-github method named method, and return its response and error.
+func (c *Client) do(method string, fn func(ctx context.Context) (*github.Response, error)) (*github.Response, error) {
+	ctx := c.requestContext()
+	sleep := c.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		resp, err := c.observe(method, ctx, fn)
+		wait, ok := retryWait(resp, err)
+		if !ok || attempt >= c.MaxRetries || waited+wait > c.MaxRetryWait {
+			return resp, err
+		}
+		c.logger().Warnf("Github rate limit hit, retrying in %s: %s", wait, err.Error())
+		if sleep(ctx, wait) != nil {
+			return resp, err
+		}
+		waited += wait
+	}
+}
+
+// sleepContext waits for d, returning early with the context's error if ctx
+// is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// observe calls fn, reporting it to the Client's Metrics if it has any. fn
+// gets the Client's CallTimeout unless ctx already has a deadline
+func (c *Client) observe(method string, ctx context.Context, fn func(ctx context.Context) (*github.Response, error)) (*github.Response, error) {
+	if _, ok := ctx.Deadline(); !ok && c.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.CallTimeout)
+		defer cancel()
+	}
+	if c.Metrics == nil {
+		return fn(ctx)
+	}
+	c.Metrics.IncRequest(method)
+	start := time.Now()
+	resp, err := fn(ctx)
+	c.Metrics.ObserveLatency(method, time.Since(start))
+	if err != nil {
+		c.Metrics.IncError(method)
+	}
+	return resp, err
+}
+
+// retryWait reports how long to wait before retrying after err, and whether
+// err is a rate limit error that can be retried at all. When Github sends both
+// a Retry-After header and an exhausted rate limit, the longer wait is used
+func retryWait(resp *github.Response, err error) (time.Duration, bool) {
+	var wait time.Duration
+	switch e := err.(type) {
+	case *github.RateLimitError:
+		wait = time.Until(e.Rate.Reset.Time)
+	case *github.AbuseRateLimitError:
+		wait = defaultAbuseRetryWait
+		if e.RetryAfter != nil {
+			wait = *e.RetryAfter
+		}
+	default:
+		// Github sometimes asks for a retry with a plain 403
+		if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+			return 0, false
+		}
+		if _, ok := retryAfter(resp.Header); !ok {
+			return 0, false
+		}
+	}
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header); ok && after > wait {
+			wait = after
+		}
+		if reset, ok := rateLimitReset(resp.Header); ok && reset > wait {
+			wait = reset
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// retryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+// rateLimitReset returns how long until the rate limit resets, if it has run
+// out. Every response has an X-RateLimit-Reset, it only matters once
+// X-RateLimit-Remaining is 0
+func rateLimitReset(h http.Header) (time.Duration, bool) {
+	if h.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Until(time.Unix(reset, 0)), true
+}
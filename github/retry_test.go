@@ -0,0 +1,322 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// abuseHandler responds with an abuse rate limit error to the first failures
+// requests, then with body
+func abuseHandler(failures int, body string, calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{
+				"message": "You have triggered an abuse detection mechanism.",
+				"documentation_url": "https://developer.github.com/v3/#abuse-rate-limits"
+			}`)
+			return
+		}
+		fmt.Fprint(w, body)
+	}
+}
+
+func TestRetryAbuseRateLimit(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", abuseHandler(1, `{"name": "r"}`, &calls))
+
+	found, err := c.checkGithubRepo("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !found {
+		t.Error("expected repo to be found")
+	}
+	if calls != 2 {
+		t.Errorf("got %d API calls, want 2", calls)
+	}
+}
+
+func TestRetryRateLimit(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "API rate limit exceeded for xxx.xxx.xxx.xxx."}`)
+			return
+		}
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+
+	found, err := c.checkGithubRepo("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !found {
+		t.Error("expected repo to be found")
+	}
+	if calls != 2 {
+		t.Errorf("got %d API calls, want 2", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.MaxRetries = 2
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", abuseHandler(10, `{"name": "r"}`, &calls))
+
+	_, err := c.checkGithubRepo("o", "r")
+	var abuseErr *github.AbuseRateLimitError
+	if !errors.As(err, &abuseErr) {
+		t.Fatalf("got error %v, want *github.AbuseRateLimitError", err)
+	}
+	if calls != 3 {
+		t.Errorf("got %d API calls, want 3", calls)
+	}
+}
+
+func TestRetryWaitTooLong(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.MaxRetryWait = time.Second
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"documentation_url": "https://developer.github.com/v3/#abuse-rate-limits"}`)
+	})
+
+	if _, err := c.checkGithubRepo("o", "r"); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("got %d API calls, want 1", calls)
+	}
+}
+
+// recordSleeps replaces the Client's sleep so tests don't wait, returning
+// the waits it was asked for
+func recordSleeps(c *Client) *[]time.Duration {
+	var waits []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return &waits
+}
+
+func TestRetryAfterForbidden(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	waits := recordSleeps(c)
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "Please wait a few seconds before trying again."}`)
+			return
+		}
+		fmt.Fprint(w, `{"name": "r"}`)
+	})
+
+	found, err := c.checkGithubRepo("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !found {
+		t.Error("expected repo to be found")
+	}
+	if calls != 2 {
+		t.Errorf("got %d API calls, want 2", calls)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 2*time.Second {
+		t.Errorf("got waits %v, want [2s]", *waits)
+	}
+}
+
+func TestRetryForbiddenWithoutRetryAfter(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	waits := recordSleeps(c)
+
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+	})
+
+	if _, err := c.checkGithubRepo("o", "r"); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if len(*waits) != 0 {
+		t.Errorf("got waits %v, want none", *waits)
+	}
+}
+
+func TestRetryWaitUsesLongerWait(t *testing.T) {
+	forbidden := func(header http.Header) (*github.Response, error) {
+		resp := &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden, Header: header}}
+		return resp, &github.ErrorResponse{Response: resp.Response}
+	}
+	exhausted := func(retryAfter string, reset time.Duration) http.Header {
+		h := http.Header{}
+		if retryAfter != "" {
+			h.Set("Retry-After", retryAfter)
+		}
+		h.Set("X-RateLimit-Remaining", "0")
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(reset).Unix(), 10))
+		return h
+	}
+
+	tests := []struct {
+		name     string
+		header   http.Header
+		min, max time.Duration
+	}{
+		{"reset is later", exhausted("1", 30*time.Second), 28 * time.Second, 30 * time.Second},
+		{"Retry-After is later", exhausted("45", 5*time.Second), 45 * time.Second, 45 * time.Second},
+		{"rate limit not exhausted", http.Header{
+			"Retry-After":           {"2"},
+			"X-Ratelimit-Remaining": {"4000"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+		}, 2 * time.Second, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		wait, ok := retryWait(forbidden(tt.header))
+		if !ok {
+			t.Errorf("%s: expected a retry", tt.name)
+			continue
+		}
+		if wait < tt.min || wait > tt.max {
+			t.Errorf("%s: got wait %s, want between %s and %s", tt.name, wait, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRetryTotalWaitCapped(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	waits := recordSleeps(c)
+	c.MaxRetries = 5
+	c.MaxRetryWait = 3 * time.Second
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Please wait a few seconds before trying again."}`)
+	})
+
+	if _, err := c.checkGithubRepo("o", "r"); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	// the second wait would take the total to 4s
+	if calls != 2 || len(*waits) != 1 {
+		t.Errorf("got %d API calls and waits %v, want 2 calls and one wait", calls, *waits)
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	ctx, cancel := context.WithCancel(context.Background())
+	c = c.WithContext(ctx)
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// cancelled while the Client waits to retry
+		time.AfterFunc(10*time.Millisecond, cancel)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Please wait a few seconds before trying again."}`)
+	})
+
+	start := time.Now()
+	if _, err := c.checkGithubRepo("o", "r"); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("took %s, want to stop waiting when the context is done", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("got %d API calls, want 1", calls)
+	}
+}
+
+// stuckHandler never responds, returning once the request is cancelled
+func stuckHandler(w http.ResponseWriter, r *http.Request) {
+	<-r.Context().Done()
+}
+
+func TestCallTimeout(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.CallTimeout = time.Millisecond
+	mux.HandleFunc("/repos/o/r", stuckHandler)
+
+	_, _, err := c.CheckRepoAccess("o", "r")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCallTimeoutUsesContextDeadline(t *testing.T) {
+	c := NewClient("")
+	deadline := func(c *Client) (time.Time, bool) {
+		var d time.Time
+		var ok bool
+		c.do("Test.Deadline", func(ctx context.Context) (*github.Response, error) {
+			d, ok = ctx.Deadline()
+			return nil, nil
+		})
+		return d, ok
+	}
+
+	// without a deadline of its own, each call gets CallTimeout
+	c.CallTimeout = time.Hour
+	if d, ok := deadline(c); !ok || time.Until(d) > time.Hour {
+		t.Errorf("got deadline %s, %t, want one within CallTimeout", d, ok)
+	}
+
+	// a context's deadline is used whether it's shorter or longer
+	for _, timeout := range []time.Duration{time.Minute, 2 * time.Hour} {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		want, _ := ctx.Deadline()
+		if d, ok := deadline(c.WithContext(ctx)); !ok || !d.Equal(want) {
+			t.Errorf("context timeout %s: got deadline %s, want %s", timeout, d, want)
+		}
+		cancel()
+	}
+
+	c.CallTimeout = 0
+	if d, ok := deadline(c); ok {
+		t.Errorf("got deadline %s with no CallTimeout, want none", d)
+	}
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// SearchOptions narrows and orders a search
+type SearchOptions struct {
+	// Org limits results to an organization, and Repo to one of its repos
+	Org  string
+	Repo string
+	// State is "open" or "closed", and only applies to issue searches
+	State string
+	// Sort and Order are passed to Github as is, see
+	// https://developer.github.com/v3/search/
+	Sort  string
+	Order string
+	// Limit is how many results to return, following as many pages as it
+	// takes. Zero returns just the first page. Rate limited pages are
+	// retried like any other request, after the search rate limit resets
+	Limit int
+}
+
+// listOptions returns the paging options for a search with opts
+func (opts SearchOptions) listOptions(perPage int) github.ListOptions {
+	if opts.Limit <= 0 {
+		return github.ListOptions{}
+	}
+	if perPage <= 0 || opts.Limit < perPage {
+		perPage = opts.Limit
+	}
+	return github.ListOptions{PerPage: perPage}
+}
+
+// qualifiers returns the search qualifiers for the options, e.g. "repo:o/r"
+func (opts SearchOptions) qualifiers() []string {
+	var q []string
+	switch {
+	case opts.Org != "" && opts.Repo != "":
+		q = append(q, "repo:"+opts.Org+"/"+opts.Repo)
+	case opts.Org != "":
+		q = append(q, "org:"+opts.Org)
+	}
+	return q
+}
+
+// issueSearchQuery builds the Github search query for SearchIssues
+func issueSearchQuery(query string, opts SearchOptions) string {
+	q := append([]string{query, "is:issue"}, opts.qualifiers()...)
+	if opts.State != "" {
+		q = append(q, "state:"+opts.State)
+	}
+	return strings.Join(q, " ")
+}
+
+// SearchIssues searches for issues matching query and returns the first page
+// of results, or the first opts.Limit, along with the total number of matches.
+// opts.Repo is looked for in opts.Org, or the Client's Org if that's empty
+func (c *Client) SearchIssues(query string, opts SearchOptions) ([]IssueSummary, int, error) {
+	if opts.Repo != "" && opts.Org == "" {
+		opts.Org = c.Org
+		if opts.Org == "" {
+			return nil, 0, errors.New("An org is required to search a repo's issues")
+		}
+	}
+	if opts.Org != "" {
+		if err := c.allowOrg(opts.Org); err != nil {
+			return nil, 0, err
+		}
+	}
+	lo := opts.listOptions(c.PerPage)
+	var total int
+	fetch := func() ([]github.Issue, *github.Response, error) {
+		var result *github.IssuesSearchResult
+		resp, err := c.do("Search.Issues", func(ctx context.Context) (resp *github.Response, err error) {
+			result, resp, err = c.client.Search.Issues(ctx, issueSearchQuery(query, opts), &github.SearchOptions{
+				Sort:        opts.Sort,
+				Order:       opts.Order,
+				ListOptions: lo,
+			})
+			return
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		total = result.GetTotal()
+		return result.Issues, resp, nil
+	}
+	var found []github.Issue
+	var resp *github.Response
+	var err error
+	if opts.Limit > 0 {
+		found, resp, err = paginateLimit(&lo, opts.Limit, fetch)
+	} else {
+		found, resp, err = fetch()
+	}
+	if err != nil {
+		return nil, 0, searchError(resp, err)
+	}
+	var issues []IssueSummary
+	for _, i := range found {
+		issues = append(issues, IssueSummary{
+			Number:   i.GetNumber(),
+			Title:    i.GetTitle(),
+			URL:      i.GetHTMLURL(),
+			Assignee: i.GetAssignee().GetLogin(),
+		})
+	}
+	return issues, total, nil
+}
+
+// searchError explains errors from the search API. Search has a much lower
+// rate limit than the rest of the API, so running out deserves a clear message
+func searchError(resp *github.Response, err error) error {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return fmt.Errorf("Github search rate limit exceeded, try again after %s: %w",
+			rateErr.Rate.Reset.Format("15:04:05 MST"), err)
+	}
+	return fmt.Errorf("Error occurred when searching Github: %w", newAPIError(resp, err))
+}
+
+// CodeResult is a file that matched a code search
+type CodeResult struct {
+	Repo    string
+	Path    string
+	HTMLURL string
+}
+
+// SearchCode searches for files containing query. Results are limited to
+// opts.Org, or the Client's Org if that's empty. Github only allows
+// authenticated code searches, so ErrAuthRequired is returned without an API key
+func (c *Client) SearchCode(query string, opts SearchOptions) ([]CodeResult, error) {
+	if !c.authenticated {
+		return nil, fmt.Errorf("Can't search code: %w", ErrAuthRequired)
+	}
+	if opts.Org == "" {
+		opts.Org = c.Org
+	}
+	if opts.Org == "" {
+		return nil, errors.New("An org is required to search code")
+	}
+	if err := c.allowOrg(opts.Org); err != nil {
+		return nil, err
+	}
+	q := strings.Join(append([]string{query}, opts.qualifiers()...), " ")
+	lo := opts.listOptions(c.PerPage)
+	fetch := func() ([]github.CodeResult, *github.Response, error) {
+		var result *github.CodeSearchResult
+		resp, err := c.do("Search.Code", func(ctx context.Context) (resp *github.Response, err error) {
+			result, resp, err = c.client.Search.Code(ctx, q, &github.SearchOptions{
+				Sort:        opts.Sort,
+				Order:       opts.Order,
+				ListOptions: lo,
+			})
+			return
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.CodeResults, resp, nil
+	}
+	var found []github.CodeResult
+	var resp *github.Response
+	var err error
+	if opts.Limit > 0 {
+		found, resp, err = paginateLimit(&lo, opts.Limit, fetch)
+	} else {
+		found, resp, err = fetch()
+	}
+	if err != nil {
+		return nil, searchError(resp, err)
+	}
+	var files []CodeResult
+	for _, r := range found {
+		files = append(files, CodeResult{
+			Repo:    r.GetRepository().GetFullName(),
+			Path:    r.GetPath(),
+			HTMLURL: r.GetHTMLURL(),
+		})
+	}
+	return files, nil
+}
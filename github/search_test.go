@@ -0,0 +1,178 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIssueSearchQuery(t *testing.T) {
+	tests := []struct {
+		opts SearchOptions
+		want string
+	}{
+		{SearchOptions{}, "panic in worker is:issue"},
+		{SearchOptions{Org: "o"}, "panic in worker is:issue org:o"},
+		{SearchOptions{Org: "o", Repo: "r", State: "open"}, "panic in worker is:issue repo:o/r state:open"},
+	}
+	for _, tt := range tests {
+		if got := issueSearchQuery("panic in worker", tt.opts); got != tt.want {
+			t.Errorf("issueSearchQuery(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestSearchIssues(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("q"), "panic is:issue org:o"; got != want {
+			t.Errorf("got query %q, want %q", got, want)
+		}
+		if got, want := r.URL.Query().Get("sort"), "updated"; got != want {
+			t.Errorf("got sort %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"total_count": 12, "items": [{"number": 3, "title": "panic", "html_url": "u3"}]}`)
+	})
+
+	issues, total, err := c.SearchIssues("panic", SearchOptions{Org: "o", Sort: "updated"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if total != 12 {
+		t.Errorf("got total %d, want 12", total)
+	}
+	want := []IssueSummary{{Number: 3, Title: "panic", URL: "u3"}}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("got %+v, want %+v", issues, want)
+	}
+	if got, want := FormatIssueSearch(issues, total), "*Showing 1 of 12 matching issues:*\n• #3 panic u3"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSearchIssuesRepoWithoutOrg(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("q"), "panic is:issue repo:handwritingio/r"; got != want {
+			t.Errorf("got query %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"total_count": 0, "items": []}`)
+	})
+
+	c.Org = "handwritingio"
+	if _, _, err := c.SearchIssues("panic", SearchOptions{Repo: "r"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	c.AllowedOrgs = []string{"other"}
+	if _, _, err := c.SearchIssues("panic", SearchOptions{Repo: "r"}); !errors.Is(err, ErrOrgNotAllowed) {
+		t.Errorf("got error %v, want %v", err, ErrOrgNotAllowed)
+	}
+
+	c.Org = ""
+	c.AllowedOrgs = nil
+	if _, _, err := c.SearchIssues("panic", SearchOptions{Repo: "r"}); err == nil {
+		t.Error("searching a repo without an org should fail")
+	}
+}
+
+func TestSearchIssuesLimit(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	var pages []string
+	results := pagesHandler(
+		`{"total_count": 5, "items": [{"number": 1}, {"number": 2}]}`,
+		`{"total_count": 5, "items": [{"number": 3}, {"number": 4}]}`,
+		`{"total_count": 5, "items": [{"number": 5}]}`,
+	)
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got != "3" {
+			t.Errorf("got per_page %q, want 3", got)
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		results(w, r)
+	})
+
+	c.PerPage = 100
+	issues, total, err := c.SearchIssues("panic", SearchOptions{Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got []int
+	for _, i := range issues {
+		got = append(got, i.Number)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got issues %v, want %v", got, want)
+	}
+	if total != 5 {
+		t.Errorf("got total %d, want 5", total)
+	}
+	if want := []string{"", "2"}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("fetched pages %q, want %q", pages, want)
+	}
+}
+
+func TestSearchIssuesRateLimited(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1500000000")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "API rate limit exceeded for 127.0.0.1."}`)
+	})
+
+	_, _, err := c.SearchIssues("panic", SearchOptions{})
+	if err == nil || !strings.HasPrefix(err.Error(), "Github search rate limit exceeded") {
+		t.Errorf("got error %v, want a rate limit message", err)
+	}
+}
+
+func TestSearchCode(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+	c.Org = "o"
+
+	mux.HandleFunc("/search/code", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("q"), "SENTRY_DSN org:o"; got != want {
+			t.Errorf("got query %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"total_count": 1, "items": [
+			{"path": "config/config.go", "html_url": "u", "repository": {"full_name": "o/r"}}
+		]}`)
+	})
+
+	files, err := c.SearchCode("SENTRY_DSN", SearchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []CodeResult{{Repo: "o/r", Path: "config/config.go", HTMLURL: "u"}}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("got %+v, want %+v", files, want)
+	}
+}
+
+func TestSearchCodeUnauthenticated(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/search/code", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unauthenticated code search should not reach Github")
+	})
+
+	if _, err := c.SearchCode("SENTRY_DSN", SearchOptions{Org: "o"}); !errors.Is(err, ErrAuthRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAuthRequired)
+	}
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/github"
+)
+
+// Team is a team in a Github org
+type Team struct {
+	Slug    string
+	Name    string
+	Members int
+}
+
+// ListTeams returns the teams in an org the Client can see. Github only
+// reports member counts for one team at a time, so this makes an API call
+// per team as well. Requires an API key with org read access
+func (c *Client) ListTeams(org string) ([]Team, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	if !c.authenticated {
+		return nil, fmt.Errorf("Can't list teams for %s: %w", org, ErrAuthRequired)
+	}
+	teams, err := c.listTeams(org)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Team, 0, len(teams))
+	for _, t := range teams {
+		var team *github.Team
+		resp, err := c.do("Teams.GetTeam", func(ctx context.Context) (resp *github.Response, err error) {
+			team, resp, err = c.client.Teams.GetTeam(ctx, t.GetID())
+			return
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Could not fetch team %s: %w", t.GetSlug(), newAPIError(resp, err))
+		}
+		out = append(out, Team{
+			Slug:    team.GetSlug(),
+			Name:    team.GetName(),
+			Members: team.GetMembersCount(),
+		})
+	}
+	return out, nil
+}
+
+// ListTeamMembers returns the logins of the members of the team with the
+// slug teamSlug, or ErrTeamNotFound if there's no such team. Requires an API
+// key with org read access
+func (c *Client) ListTeamMembers(org, teamSlug string) ([]string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	if !c.authenticated {
+		return nil, fmt.Errorf("Can't list members of %s: %w", teamSlug, ErrAuthRequired)
+	}
+	teams, err := c.listTeams(org)
+	if err != nil {
+		return nil, err
+	}
+	var id int64
+	for _, t := range teams {
+		if t.GetSlug() == teamSlug {
+			id = t.GetID()
+			break
+		}
+	}
+	if id == 0 {
+		return nil, fmt.Errorf("%w: %s in %s", ErrTeamNotFound, teamSlug, org)
+	}
+
+	opt := &github.TeamListTeamMembersOptions{
+		ListOptions: github.ListOptions{PerPage: c.PerPage},
+	}
+	all, resp, err := paginate(&opt.ListOptions, func() ([]*github.User, *github.Response, error) {
+		var page []*github.User
+		resp, err := c.do("Teams.ListTeamMembers", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Teams.ListTeamMembers(ctx, id, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch members of %s: %w", teamSlug, newAPIError(resp, err))
+	}
+	var members []string
+	for _, u := range all {
+		members = append(members, u.GetLogin())
+	}
+	return members, nil
+}
+
+// listTeams pages through all teams in an org
+func (c *Client) listTeams(org string) ([]*github.Team, error) {
+	opt := &github.ListOptions{PerPage: c.PerPage}
+	teams, resp, err := paginate(opt, func() ([]*github.Team, *github.Response, error) {
+		var page []*github.Team
+		resp, err := c.do("Teams.ListTeams", func(ctx context.Context) (resp *github.Response, err error) {
+			page, resp, err = c.client.Teams.ListTeams(ctx, org, opt)
+			return
+		})
+		return page, resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("Could not fetch teams for %s: the org doesn't exist or the API key can't read it", org)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch teams for %s: %w", org, newAPIError(resp, err))
+	}
+	return teams, nil
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestListTeams(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	mux.HandleFunc("/orgs/o/teams", pagesHandler(
+		`[{"id": 1, "slug": "core", "name": "Core"}]`,
+		`[{"id": 2, "slug": "ops", "name": "Ops"}]`,
+	))
+	mux.HandleFunc("/teams/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "slug": "core", "name": "Core", "members_count": 3}`)
+	})
+	mux.HandleFunc("/teams/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 2, "slug": "ops", "name": "Ops", "members_count": 1}`)
+	})
+
+	got, err := c.ListTeams("o")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []Team{
+		{Slug: "core", Name: "Core", Members: 3},
+		{Slug: "ops", Name: "Ops", Members: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListTeamMembers(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+	c.authenticated = true
+
+	mux.HandleFunc("/orgs/o/teams", pagesHandler(
+		`[{"id": 1, "slug": "core", "name": "Core"}]`,
+		`[{"id": 2, "slug": "ops", "name": "Ops"}]`,
+	))
+	mux.HandleFunc("/teams/2/members", pagesHandler(
+		`[{"login": "alice"}, {"login": "bob"}]`,
+		`[{"login": "carol"}]`,
+	))
+
+	got, err := c.ListTeamMembers("o", "ops")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"alice", "bob", "carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := c.ListTeamMembers("o", "missing"); !errors.Is(err, ErrTeamNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrTeamNotFound)
+	}
+}
+
+func TestListTeamsRequiresAuth(t *testing.T) {
+	c := NewClient("")
+	if _, err := c.ListTeams("o"); !errors.Is(err, ErrAuthRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAuthRequired)
+	}
+	if _, err := c.ListTeamMembers("o", "core"); !errors.Is(err, ErrAuthRequired) {
+		t.Errorf("got error %v, want %v", err, ErrAuthRequired)
+	}
+}
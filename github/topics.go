@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// maxTopics is the most topics Github allows on a repo
+const maxTopics = 20
+
+// topicPattern is what Github allows in a topic: lowercase letters, numbers
+// and hyphens, starting with a letter or number, at most 50 characters
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// GetRepoTopics returns the topics of a repo
+func (c *Client) GetRepoTopics(org, repo string) ([]string, error) {
+	if err := c.allowOrg(org); err != nil {
+		return nil, err
+	}
+	var topics []string
+	resp, err := c.do("Repositories.ListAllTopics", func(ctx context.Context) (resp *github.Response, err error) {
+		topics, resp, err = c.client.Repositories.ListAllTopics(ctx, org, repo)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch topics for %s: %w", repo, newAPIError(resp, err))
+	}
+	return topics, nil
+}
+
+// SetRepoTopics replaces the topics of a repo. Topics are lowercased, and
+// duplicates dropped, before they're checked against Github's rules. An empty
+// list removes all topics
+func (c *Client) SetRepoTopics(org, repo string, topics []string) error {
+	if err := c.allowOrg(org); err != nil {
+		return err
+	}
+	normalized, err := normalizeTopics(topics)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.dryRun("set the topics of %s/%s to %s", org, repo, strings.Join(normalized, ", ")); ok {
+		return nil
+	}
+	resp, err := c.do("Repositories.ReplaceAllTopics", func(ctx context.Context) (resp *github.Response, err error) {
+		_, resp, err = c.client.Repositories.ReplaceAllTopics(ctx, org, repo, normalized)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+	}
+	if err != nil {
+		return fmt.Errorf("Error occurred when setting topics for %s: %w", repo, newAPIError(resp, err))
+	}
+	return nil
+}
+
+// normalizeTopics lowercases topics and drops duplicates, returning an error
+// for any Github wouldn't accept
+func normalizeTopics(topics []string) ([]string, error) {
+	seen := make(map[string]bool)
+	normalized := []string{}
+	for _, t := range topics {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if !topicPattern.MatchString(t) {
+			return nil, fmt.Errorf("Invalid topic %q: topics are up to 50 lowercase letters, numbers and hyphens, and can't start with a hyphen", t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			normalized = append(normalized, t)
+		}
+	}
+	if len(normalized) > maxTopics {
+		return nil, fmt.Errorf("Too many topics: Github allows at most %d", maxTopics)
+	}
+	return normalized, nil
+}
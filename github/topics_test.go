@@ -0,0 +1,63 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetRepoTopics(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/topics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"names": ["chatbot", "golang"]}`)
+	})
+
+	got, err := c.GetRepoTopics("o", "r")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"chatbot", "golang"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSetRepoTopics(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/repos/o/r/topics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("got method %s, want PUT", r.Method)
+		}
+		var got struct{ Names []string }
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"chatbot", "slack-bot"}; !reflect.DeepEqual(got.Names, want) {
+			t.Errorf("got topics %v, want %v", got.Names, want)
+		}
+		fmt.Fprint(w, `{"names": ["chatbot", "slack-bot"]}`)
+	})
+
+	if err := c.SetRepoTopics("o", "r", []string{"ChatBot", " slack-bot", "chatbot"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestSetRepoTopicsInvalid(t *testing.T) {
+	c, _, teardown := setup()
+	defer teardown()
+
+	// no handlers are registered, so any API call would fail with a 404
+	for _, topic := range []string{"-bot", "slack_bot", "chat bot", "", strings.Repeat("a", 51)} {
+		err := c.SetRepoTopics("o", "r", []string{topic})
+		if err == nil || !strings.HasPrefix(err.Error(), "Invalid topic") {
+			t.Errorf("topic %q: got error %v, want an invalid topic error", topic, err)
+		}
+	}
+}
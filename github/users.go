@@ -0,0 +1,41 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/github"
+)
+
+// UserInfo is a Github user's public profile
+type UserInfo struct {
+	Login   string
+	Name    string
+	Company string
+	// Email is only set if the user has made it public
+	Email   string
+	HTMLURL string
+}
+
+// GetUser returns the profile of the user with login, or ErrUserNotFound
+func (c *Client) GetUser(login string) (*UserInfo, error) {
+	var u *github.User
+	resp, err := c.do("Users.Get", func(ctx context.Context) (resp *github.Response, err error) {
+		u, resp, err = c.client.Users.Get(ctx, login)
+		return
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, login)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not fetch Github user %s: %w", login, newAPIError(resp, err))
+	}
+	return &UserInfo{
+		Login:   u.GetLogin(),
+		Name:    u.GetName(),
+		Company: u.GetCompany(),
+		Email:   u.GetEmail(),
+		HTMLURL: u.GetHTMLURL(),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetUser(t *testing.T) {
+	c, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/deckard", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"login": "deckard",
+			"name": "Rick Deckard",
+			"company": "LAPD",
+			"email": null,
+			"html_url": "https://github.com/deckard"
+		}`)
+	})
+	mux.HandleFunc("/users/nobody", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	got, err := c.GetUser("deckard")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &UserInfo{Login: "deckard", Name: "Rick Deckard", Company: "LAPD", HTMLURL: "https://github.com/deckard"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := c.GetUser("nobody"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrUserNotFound)
+	}
+}
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/github"
+)
+
+// maxWebhookPayload is the largest payload Github will send
+const maxWebhookPayload = 25 << 20
+
+// WebhookCallback is called with a parsed Github event, e.g. a
+// *github.PullRequestEvent for "pull_request" events
+type WebhookCallback func(event interface{})
+
+// WebhookServer is an http.Handler that receives Github webhooks, checks
+// their signature and dispatches them to the callbacks registered with On
+type WebhookServer struct {
+	// Logger is where rejected deliveries are logged. It defaults to the
+	// log package.
+	Logger Logger
+
+	secret string
+
+	mu        sync.RWMutex
+	callbacks map[string][]WebhookCallback
+}
+
+// NewWebhookServer returns a WebhookServer that only accepts events signed
+// with secret, the secret configured on the Github webhook. Anyone can sign
+// with an empty secret, so a WebhookServer without one rejects every event
+func NewWebhookServer(secret string) *WebhookServer {
+	return &WebhookServer{
+		Logger:    defaultLogger{},
+		secret:    secret,
+		callbacks: make(map[string][]WebhookCallback),
+	}
+}
+
+// On registers callback for events of eventType, the X-GitHub-Event header
+// value such as "push", "issues" or "pull_request"
+func (s *WebhookServer) On(eventType string, callback WebhookCallback) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks[eventType] = append(s.callbacks[eventType], callback)
+}
+
+// logger returns the WebhookServer's Logger, or the default one if it isn't set
+func (s *WebhookServer) logger() Logger {
+	if s.Logger == nil {
+		return defaultLogger{}
+	}
+	return s.Logger
+}
+
+// ServeHTTP handles a webhook delivery. Requests without a valid
+// X-Hub-Signature-256 get a 401, as do all requests if there's no secret.
+// Callbacks are run before responding, and Github gives up on a delivery
+// after 10 seconds, so slow callbacks should start a goroutine
+func (s *WebhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.secret == "" {
+		s.logger().Warnf("Rejected Github webhook %s: no webhook secret is configured", github.DeliveryID(r))
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayload))
+	if err != nil {
+		http.Error(w, "Could not read payload", http.StatusBadRequest)
+		return
+	}
+	if err := ValidateSignature(body, r.Header.Get("X-Hub-Signature-256"), s.secret); err != nil {
+		s.logger().Warnf("Rejected Github webhook %s: %s", github.DeliveryID(r), err.Error())
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	payload, err := webhookPayload(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	eventType := github.WebHookType(r)
+	event, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		http.Error(w, "Could not parse event: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.RLock()
+	callbacks := s.callbacks[eventType]
+	s.mu.RUnlock()
+	for _, callback := range callbacks {
+		callback(event)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidateSignature checks that signature, an X-Hub-Signature-256 header
+// formatted "sha256=<hex>", is the HMAC of payload keyed with secret.
+// Returns ErrMissingSignature, ErrMalformedSignature or ErrInvalidSignature
+func ValidateSignature(payload []byte, signature, secret string) error {
+	const prefix = "sha256="
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	if !strings.HasPrefix(signature, prefix) {
+		return fmt.Errorf("%w: expected a %s prefix", ErrMalformedSignature, prefix)
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrMalformedSignature, err.Error())
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	// hmac.Equal takes the same time however much of the signature matches
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// webhookPayload returns the JSON payload of a webhook body, which Github
+// sends either as is or as a form field depending on the webhook's settings
+func webhookPayload(contentType string, body []byte) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return body, nil
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		return []byte(form.Get("payload")), nil
+	}
+	return nil, errors.New("Unsupported Content-Type " + contentType)
+}
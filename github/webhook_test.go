@@ -0,0 +1,130 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func sign(body, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookServer(t *testing.T) {
+	const payload = `{"action": "opened", "number": 7, "pull_request": {"title": "Fix it"}}`
+	form := "payload=" + url.QueryEscape(payload)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		signature   string
+		wantStatus  int
+		wantCalled  bool
+	}{
+		{"valid", "application/json", payload, sign(payload, "s3cret"), http.StatusNoContent, true},
+		{"valid form", "application/x-www-form-urlencoded", form, sign(form, "s3cret"), http.StatusNoContent, true},
+		{"tampered body", "application/json", strings.Replace(payload, "7", "8", 1), sign(payload, "s3cret"), http.StatusUnauthorized, false},
+		{"wrong secret", "application/json", payload, sign(payload, "guess"), http.StatusUnauthorized, false},
+		{"missing signature", "application/json", payload, "", http.StatusUnauthorized, false},
+		{"malformed signature", "application/json", payload, "sha256=zz", http.StatusUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewWebhookServer("s3cret")
+			called := false
+			s.On("pull_request", func(event interface{}) {
+				called = true
+				e, ok := event.(*github.PullRequestEvent)
+				if !ok {
+					t.Fatalf("got event %T, want *github.PullRequestEvent", event)
+				}
+				if e.GetNumber() != 7 || e.GetPullRequest().GetTitle() != "Fix it" {
+					t.Errorf("got event %+v", e)
+				}
+			})
+			s.On("push", func(event interface{}) {
+				t.Error("push callback called for a pull_request event")
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			r.Header.Set("X-GitHub-Event", "pull_request")
+			if tt.signature != "" {
+				r.Header.Set("X-Hub-Signature-256", tt.signature)
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, tt.wantStatus)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("got callback called %t, want %t", called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestWebhookServerEmptySecret(t *testing.T) {
+	const payload = `{"action": "opened", "number": 7}`
+	s := NewWebhookServer("")
+	s.On("pull_request", func(event interface{}) {
+		t.Error("callback called without a webhook secret")
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-GitHub-Event", "pull_request")
+	r.Header.Set("X-Hub-Signature-256", sign(payload, ""))
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestValidateSignature(t *testing.T) {
+	// The example from Github's webhook documentation
+	const (
+		secret    = "It's a Secret to Everybody"
+		payload   = "Hello, World!"
+		signature = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	)
+
+	tests := []struct {
+		name      string
+		payload   string
+		signature string
+		want      error
+	}{
+		{"valid", payload, signature, nil},
+		{"tampered body", "Hello, World?", signature, ErrInvalidSignature},
+		{"last byte differs", payload, signature[:len(signature)-1] + "8", ErrInvalidSignature},
+		{"truncated", payload, signature[:21], ErrInvalidSignature},
+		{"empty", payload, "", ErrMissingSignature},
+		{"sha1 prefix", payload, "sha1=" + signature[len("sha256="):], ErrMalformedSignature},
+		{"not hex", payload, "sha256=xyz", ErrMalformedSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSignature([]byte(tt.payload), tt.signature, secret)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
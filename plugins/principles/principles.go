@@ -3,11 +3,13 @@
 package principles
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/handwritingio/deckard-bot/github"
 	"github.com/handwritingio/deckard-bot/log"
@@ -46,6 +48,9 @@ var (
 	principleOrg      = "handwritingio"
 	principleRepo     = "principles"
 	principleFilename = "EngineeringPrinciples.md"
+
+	// githubTimeout is how long to wait on Github before giving up
+	githubTimeout = 10 * time.Second
 )
 
 // Usage returns the Plugin's usage
@@ -188,8 +193,10 @@ func (p *Plugin) fuzzySearch(keyword string) *Principle {
 
 // getPrinciples returns the data from from the EngineeringPrinciples.md file in Github
 func getPrinciples() ([]byte, error) {
-	githubClient := github.NewClient("")
-	contents, _, err := githubClient.GetFile(principleOrg, principleRepo, principleFilename)
+	ctx, cancel := context.WithTimeout(context.Background(), githubTimeout)
+	defer cancel()
+	githubClient := github.NewClient("").WithContext(ctx)
+	contents, _, err := githubClient.GetFile(principleOrg, principleRepo, principleFilename, "")
 	if err != nil {
 		log.Warnf("Error encountered getting file contents: %s", err.Error())
 		return nil, err